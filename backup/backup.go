@@ -0,0 +1,125 @@
+// Package backup snapshots the configuration and campaign results of a
+// gophish instance into an archive, and restores archives into instances.
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/manifest"
+)
+
+// Version is the version of the archive format written by Write. Read
+// refuses archives from newer versions.
+const Version = 1
+
+// Archive is a snapshot of a gophish instance.
+type Archive struct {
+	Version int          `json:"version"`
+	Created gophish.Time `json:"created"`
+
+	SendingProfiles []gophish.SendingProfile `json:"sending_profiles"`
+	Templates       []gophish.Template       `json:"templates"`
+	LandingPages    []gophish.LandingPage    `json:"landing_pages"`
+	Groups          []gophish.Group          `json:"groups"`
+
+	// Campaigns include their results and timelines.
+	Campaigns []gophish.Campaign `json:"campaigns"`
+}
+
+// Export snapshots every resource of the instance the client is for.
+// Sending profiles include their passwords, so archives should be kept safe.
+func Export(ctx context.Context, client *gophish.Client) (*Archive, error) {
+	return ExportKinds(ctx, client,
+		manifest.KindSendingProfile,
+		manifest.KindTemplate,
+		manifest.KindLandingPage,
+		manifest.KindGroup,
+		manifest.KindCampaign,
+	)
+}
+
+// ExportKinds is like Export, but only snapshots the resources of the given
+// kinds (see the manifest package's Kind constants), so that, i.e., the
+// results of every campaign aren't fetched for an archive of templates.
+func ExportKinds(ctx context.Context, client *gophish.Client, kinds ...string) (*Archive, error) {
+	a := &Archive{
+		Version: Version,
+		Created: gophish.NewTime(time.Now().UTC()),
+	}
+	has := func(kind string) bool {
+		for _, k := range kinds {
+			if strings.EqualFold(k, kind) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var err error
+	if has(manifest.KindSendingProfile) {
+		if a.SendingProfiles, err = client.SendingProfiles.ListSendingProfilesContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if has(manifest.KindTemplate) {
+		if a.Templates, err = client.Templates.ListTemplatesContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if has(manifest.KindLandingPage) {
+		if a.LandingPages, err = client.LandingPages.ListLandingPagesContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if has(manifest.KindGroup) {
+		if a.Groups, err = client.Groups.ListGroupsContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if has(manifest.KindCampaign) {
+		if a.Campaigns, err = client.Campaigns.ListCampaignsContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// Write writes the archive as gzipped JSON.
+func (a *Archive) Write(w io.Writer) error {
+	zw := gzip.NewWriter(w)
+	enc := json.NewEncoder(zw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(a); err != nil {
+		zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// Read reads an archive written by Write.
+func Read(r io.Reader) (*Archive, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("backup: not an archive: %v", err)
+	}
+	defer zr.Close()
+
+	var a Archive
+	if err := json.NewDecoder(zr).Decode(&a); err != nil {
+		return nil, fmt.Errorf("backup: reading archive: %v", err)
+	}
+	switch {
+	case a.Version == 0:
+		return nil, fmt.Errorf("backup: archive has no version")
+	case a.Version > Version:
+		return nil, fmt.Errorf("backup: archive version %d is newer than this version of the client supports (%d)", a.Version, Version)
+	}
+	return &a, nil
+}
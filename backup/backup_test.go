@@ -0,0 +1,385 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/gophishtest"
+	"github.com/ttacon/gophish/manifest"
+)
+
+// seed creates one resource of each kind, and a campaign using them.
+func seed(t *testing.T, client *gophish.Client) {
+	t.Helper()
+	if _, err := client.SendingProfiles.CreateSendingProfile(&gophish.SendingProfile{
+		Name:        "Corp SMTP",
+		Host:        "smtp.example.com",
+		FromAddress: "it@example.com",
+		Password:    "hunter2",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Templates.CreateTemplate(&gophish.Template{
+		Name:    "Invoice",
+		Subject: "Your invoice is overdue",
+		HTML:    "<p>Pay {{.FirstName}}</p>",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.LandingPages.CreateLandingPage(&gophish.LandingPage{
+		Name:               "Login",
+		HTML:               "<form></form>",
+		CaptureCredentials: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Groups.CreateGroup(&gophish.Group{
+		Name:    "Staff",
+		Targets: []gophish.Target{{Email: "jdoe@example.com", FirstName: "John"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Campaigns.CreateCampaign(&gophish.Campaign{
+		Name:     "Q2",
+		Template: gophish.Template{Name: "Invoice"},
+		Page:     gophish.LandingPage{Name: "Login"},
+		SMTP:     gophish.SendingProfile{Name: "Corp SMTP"},
+		Groups:   []gophish.Group{{Name: "Staff"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func exportArchive(t *testing.T, client *gophish.Client) *Archive {
+	t.Helper()
+	a, err := Export(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestRoundTrip(t *testing.T) {
+	src := gophishtest.NewServer("token")
+	defer src.Close()
+	dst := gophishtest.NewServer("token")
+	defer dst.Close()
+	from, to := src.Client(), dst.Client()
+	seed(t, from)
+
+	// Take up some IDs in the destination, so the imported resources get
+	// different ones.
+	for _, name := range []string{"Welcome", "Reset password", "Newsletter"} {
+		if _, err := to.Templates.CreateTemplate(&gophish.Template{Name: name, Text: "Hi"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exported := exportArchive(t, from)
+	var buf bytes.Buffer
+	if err := exported.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	written := buf.String()
+	a, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// What's read is what was written.
+	var rewritten bytes.Buffer
+	if err := a.Write(&rewritten); err != nil {
+		t.Fatal(err)
+	}
+	if rewritten.String() != written {
+		t.Error("the archive changed when read and written again")
+	}
+	if len(a.SendingProfiles) != 1 || a.SendingProfiles[0].Password != "hunter2" {
+		t.Errorf("got sending profiles %+v, want the password kept", a.SendingProfiles)
+	}
+	if len(a.Campaigns) != 1 || len(a.Campaigns[0].Results) != 1 {
+		t.Errorf("got campaigns %+v, want the results kept", a.Campaigns)
+	}
+
+	result, err := Import(context.Background(), to, a, ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var outcomes []string
+	for _, res := range result.Resources {
+		outcomes = append(outcomes, string(res.Outcome)+" "+res.Kind+" "+res.Name)
+	}
+	want := []string{
+		"created " + manifest.KindSendingProfile + " Corp SMTP",
+		"created " + manifest.KindTemplate + " Invoice",
+		"created " + manifest.KindLandingPage + " Login",
+		"created " + manifest.KindGroup + " Staff",
+		"skipped " + manifest.KindCampaign + " Q2",
+	}
+	if !reflect.DeepEqual(outcomes, want) {
+		t.Errorf("got %q, want %q", outcomes, want)
+	}
+
+	// Each resource maps to the one it was imported as.
+	template, err := to.Templates.GetTemplate(result.IDs(manifest.KindTemplate)[a.Templates[0].ID])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if template.Name != "Invoice" || template.HTML != a.Templates[0].HTML || template.ID == a.Templates[0].ID {
+		t.Errorf("got template %+v, want Invoice under a new ID", template)
+	}
+	group, err := to.Groups.GetGroup(result.IDs(manifest.KindGroup)[a.Groups[0].ID])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.Name != "Staff" || !reflect.DeepEqual(group.Targets, a.Groups[0].Targets) {
+		t.Errorf("got group %+v, want Staff", group)
+	}
+	sp, err := to.SendingProfiles.GetSendingProfile(result.IDs(manifest.KindSendingProfile)[a.SendingProfiles[0].ID])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sp.Name != "Corp SMTP" || sp.Password != "hunter2" {
+		t.Errorf("got sending profile %+v, want Corp SMTP with its password", sp)
+	}
+	page, err := to.LandingPages.GetLandingPage(result.IDs(manifest.KindLandingPage)[a.LandingPages[0].ID])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Name != "Login" || !page.CaptureCredentials {
+		t.Errorf("got landing page %+v, want Login", page)
+	}
+	if ids := result.IDs(manifest.KindCampaign); len(ids) != 0 {
+		t.Errorf("got campaign IDs %v, want none as campaigns aren't restored", ids)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	archive := func(version int) string {
+		var buf bytes.Buffer
+		if err := (&Archive{Version: version}).Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+	gzipped := func(s string) string {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte(s))
+		zw.Close()
+		return buf.String()
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"newer version", archive(Version + 1), "backup: archive version 2 is newer than this version of the client supports (1)"},
+		{"no version", archive(0), "backup: archive has no version"},
+		{"not gzipped", `{"version":1}`, "backup: not an archive"},
+		{"not JSON", gzipped("version: 1"), "backup: reading archive"},
+	}
+	for _, tt := range tests {
+		_, err := Read(strings.NewReader(tt.data))
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("%s: got error %v, want %q", tt.name, err, tt.want)
+		}
+	}
+
+	if _, err := Read(strings.NewReader(archive(Version))); err != nil {
+		t.Errorf("got error %v reading the current version", err)
+	}
+}
+
+func TestImportCollisions(t *testing.T) {
+	src := gophishtest.NewServer("token")
+	defer src.Close()
+	from := src.Client()
+	for _, name := range []string{"Invoice", "Welcome"} {
+		if _, err := from.Templates.CreateTemplate(&gophish.Template{Name: name, Text: "Imported"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a, err := ExportKinds(context.Background(), from, manifest.KindTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invoiceID, welcomeID := a.Templates[0].ID, a.Templates[1].ID
+
+	tests := []struct {
+		policy   CollisionPolicy
+		invoice  ImportedResource
+		contents map[string]string
+	}{
+		{
+			"",
+			ImportedResource{Outcome: OutcomeSkipped, Reason: "already exists"},
+			map[string]string{"Invoice": "Existing", "Welcome": "Imported"},
+		},
+		{
+			Skip,
+			ImportedResource{Outcome: OutcomeSkipped, Reason: "already exists"},
+			map[string]string{"Invoice": "Existing", "Welcome": "Imported"},
+		},
+		{
+			Rename,
+			ImportedResource{Outcome: OutcomeRenamed, NewName: "Invoice (2)"},
+			map[string]string{"Invoice": "Existing", "Invoice (2)": "Imported", "Welcome": "Imported"},
+		},
+		{
+			Overwrite,
+			ImportedResource{Outcome: OutcomeOverwritten},
+			map[string]string{"Invoice": "Imported", "Welcome": "Imported"},
+		},
+	}
+	for _, tt := range tests {
+		dst := gophishtest.NewServer("token")
+		to := dst.Client()
+		existing, err := to.Templates.CreateTemplate(&gophish.Template{Name: "Invoice", Text: "Existing"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Dry runs report the same, without changing anything.
+		dryRun, err := Import(context.Background(), to, a, ImportOptions{OnCollision: tt.policy, DryRun: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := Import(context.Background(), to, a, ImportOptions{OnCollision: tt.policy})
+		if err != nil {
+			t.Fatal(err)
+		}
+		templates, err := to.Templates.ListTemplates()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst.Close()
+
+		if len(result.Resources) != 2 {
+			t.Errorf("%q: got %+v, want 2 templates", tt.policy, result.Resources)
+			continue
+		}
+		invoice, welcome := result.Resources[0], result.Resources[1]
+		want := tt.invoice
+		want.Kind, want.Name, want.OldID = manifest.KindTemplate, "Invoice", invoiceID
+		switch tt.policy {
+		case Rename:
+			want.NewID = invoice.NewID
+			if want.NewID == 0 || want.NewID == existing.ID {
+				t.Errorf("%q: got new ID %d, want a new template", tt.policy, want.NewID)
+			}
+		default:
+			want.NewID = existing.ID
+		}
+		if invoice != want {
+			t.Errorf("%q: got %+v, want %+v", tt.policy, invoice, want)
+		}
+		if welcome.Outcome != OutcomeCreated || welcome.NewID == 0 {
+			t.Errorf("%q: got %+v, want Welcome created", tt.policy, welcome)
+		}
+		if ids := result.IDs(manifest.KindTemplate); ids[invoiceID] != invoice.NewID || ids[welcomeID] != welcome.NewID {
+			t.Errorf("%q: got IDs %v", tt.policy, ids)
+		}
+
+		for i, res := range dryRun.Resources {
+			if res.Outcome != result.Resources[i].Outcome || res.NewName != result.Resources[i].NewName {
+				t.Errorf("%q: dry run got %+v, want %+v", tt.policy, res, result.Resources[i])
+			}
+		}
+		// Only resources that exist already have an ID on dry runs.
+		if ids := dryRun.IDs(manifest.KindTemplate); tt.policy != Rename && !reflect.DeepEqual(ids, map[int]int{invoiceID: existing.ID}) {
+			t.Errorf("%q: dry run got IDs %v", tt.policy, ids)
+		}
+
+		contents := make(map[string]string)
+		for _, template := range templates {
+			contents[template.Name] = template.Text
+		}
+		if !reflect.DeepEqual(contents, tt.contents) {
+			t.Errorf("%q: got templates %v, want %v", tt.policy, contents, tt.contents)
+		}
+	}
+
+	dst := gophishtest.NewServer("token")
+	defer dst.Close()
+	if _, err := Import(context.Background(), dst.Client(), a, ImportOptions{OnCollision: "replace"}); err == nil {
+		t.Error("got no error for an unknown collision policy")
+	}
+}
+
+func TestImportRenamesPastTakenNames(t *testing.T) {
+	dst := gophishtest.NewServer("token")
+	defer dst.Close()
+	to := dst.Client()
+	for _, name := range []string{"Staff", "Staff (2)", "Staff (4)"} {
+		if _, err := to.Groups.CreateGroup(&gophish.Group{
+			Name:    name,
+			Targets: []gophish.Target{{Email: "jdoe@example.com"}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The archive has a group with the same name twice, i.e. from
+	// combining archives.
+	a := &Archive{Version: Version}
+	for id := 1; id <= 3; id++ {
+		a.Groups = append(a.Groups, gophish.Group{
+			ID:      id,
+			Name:    "Staff",
+			Targets: []gophish.Target{{Email: "bwayne@example.com"}},
+		})
+	}
+	for _, dryRun := range []bool{true, false} {
+		result, err := Import(context.Background(), to, a, ImportOptions{OnCollision: Rename, DryRun: dryRun})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, res := range result.Resources {
+			names = append(names, res.NewName)
+		}
+		if want := []string{"Staff (3)", "Staff (5)", "Staff (6)"}; !reflect.DeepEqual(names, want) {
+			t.Errorf("dry run %v: got names %q, want %q", dryRun, names, want)
+		}
+		if ids := result.IDs(manifest.KindGroup); dryRun && len(ids) != 0 || !dryRun && len(ids) != 3 {
+			t.Errorf("dry run %v: got IDs %v", dryRun, ids)
+		}
+	}
+}
+
+func TestFreeName(t *testing.T) {
+	imp := newImporter(manifest.KindGroup, Rename, false, &ImportResult{})
+	for _, name := range []string{"Finance", "Finance (2)", "Finance (3)", "Finance (5)", "Staff (2)"} {
+		imp.exists(name, 1)
+	}
+	tests := []struct {
+		name, want string
+	}{
+		{"Finance", "Finance (4)"},
+		{"Staff", "Staff (3)"},
+		{"Staff (2)", "Staff (2) (2)"},
+		{"IT", "IT (2)"},
+	}
+	for _, tt := range tests {
+		if got := imp.freeName(tt.name); got != tt.want {
+			t.Errorf("freeName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseCollisionPolicy(t *testing.T) {
+	for s, want := range map[string]CollisionPolicy{"skip": Skip, "Rename": Rename, "OVERWRITE": Overwrite} {
+		if got, err := ParseCollisionPolicy(s); err != nil || got != want {
+			t.Errorf("ParseCollisionPolicy(%q) = %q, %v, want %q", s, got, err, want)
+		}
+	}
+	if _, err := ParseCollisionPolicy("replace"); err == nil {
+		t.Error("got no error for an unknown policy")
+	}
+}
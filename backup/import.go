@@ -0,0 +1,334 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/manifest"
+)
+
+// CollisionPolicy is what Import does with a resource when the instance
+// already has one of the same kind and name.
+type CollisionPolicy string
+
+// The collision policies.
+const (
+	// Skip keeps the existing resource.
+	Skip CollisionPolicy = "skip"
+
+	// Rename imports the resource under a new name, i.e. "Finance (2)".
+	Rename CollisionPolicy = "rename"
+
+	// Overwrite replaces the existing resource with the imported one.
+	Overwrite CollisionPolicy = "overwrite"
+)
+
+// ParseCollisionPolicy parses the name of a collision policy.
+func ParseCollisionPolicy(s string) (CollisionPolicy, error) {
+	switch p := CollisionPolicy(strings.ToLower(s)); p {
+	case Skip, Rename, Overwrite:
+		return p, nil
+	}
+	return "", fmt.Errorf("backup: unknown collision policy %q, expected skip, rename or overwrite", s)
+}
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	// OnCollision defaults to Skip.
+	OnCollision CollisionPolicy
+
+	// DryRun works out what would be imported without changing anything.
+	DryRun bool
+
+	// Password, if set, returns the password to save each sending profile
+	// with, instead of the one in the archive. existing is the profile it
+	// overwrites, or nil if it's created. It isn't called for skipped
+	// profiles or on dry runs.
+	Password func(sp gophish.SendingProfile, existing *gophish.SendingProfile) (string, error)
+}
+
+// Outcome is what happened to a resource being imported.
+type Outcome string
+
+// The outcomes of importing a resource.
+const (
+	OutcomeCreated     Outcome = "created"
+	OutcomeRenamed     Outcome = "renamed"
+	OutcomeOverwritten Outcome = "overwritten"
+	OutcomeSkipped     Outcome = "skipped"
+)
+
+// ImportedResource is the outcome of importing one resource.
+type ImportedResource struct {
+	Kind string
+	Name string
+
+	// NewName is the name it was imported as, if it was renamed.
+	NewName string `json:",omitempty"`
+
+	// OldID is the resource's ID in the archive, and NewID its ID in the
+	// instance. NewID is 0 for dry runs and skipped resources that don't
+	// exist in the instance.
+	OldID int
+	NewID int `json:",omitempty"`
+
+	Outcome Outcome
+	Reason  string `json:",omitempty"`
+}
+
+// ImportResult is the outcome of Import.
+type ImportResult struct {
+	Resources []ImportedResource
+}
+
+// IDs maps the IDs of the resources of a kind in the archive to their IDs
+// in the instance.
+func (r *ImportResult) IDs(kind string) map[int]int {
+	ids := make(map[int]int)
+	for _, res := range r.Resources {
+		if res.Kind == kind && res.NewID != 0 {
+			ids[res.OldID] = res.NewID
+		}
+	}
+	return ids
+}
+
+// String describes the result for people, a line per resource.
+func (r *ImportResult) String() string {
+	var b strings.Builder
+	counts := make(map[Outcome]int)
+	for _, res := range r.Resources {
+		counts[res.Outcome]++
+		fmt.Fprintf(&b, "%-11s %s %q", res.Outcome, res.Kind, res.Name)
+		if res.NewName != "" {
+			fmt.Fprintf(&b, " as %q", res.NewName)
+		}
+		if res.Reason != "" {
+			fmt.Fprintf(&b, " (%s)", res.Reason)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%d created, %d renamed, %d overwritten, %d skipped.\n",
+		counts[OutcomeCreated], counts[OutcomeRenamed], counts[OutcomeOverwritten], counts[OutcomeSkipped])
+	return b.String()
+}
+
+// Import restores an archive into the instance the client is for. Resources
+// get new IDs, which the result maps. Campaigns are reported as skipped, as
+// gophish can't restore results and recreating them would send emails.
+func Import(ctx context.Context, client *gophish.Client, a *Archive, opts ImportOptions) (*ImportResult, error) {
+	policy := opts.OnCollision
+	if policy == "" {
+		policy = Skip
+	}
+	if _, err := ParseCollisionPolicy(string(policy)); err != nil {
+		return nil, err
+	}
+	result := &ImportResult{}
+
+	profiles, err := client.SendingProfiles.ListSendingProfilesContext(ctx)
+	if err != nil {
+		return result, err
+	}
+	imp := newImporter(manifest.KindSendingProfile, policy, opts.DryRun, result)
+	existing := make(map[int]*gophish.SendingProfile, len(profiles))
+	for i, sp := range profiles {
+		imp.exists(sp.Name, sp.ID)
+		existing[sp.ID] = &profiles[i]
+	}
+	for _, sp := range a.SendingProfiles {
+		sp := sp
+		err := imp.add(sp.Name, sp.ID, func(name string, id int) (int, error) {
+			sp.Name, sp.ID = name, id
+			if opts.Password != nil {
+				var err error
+				if sp.Password, err = opts.Password(sp, existing[id]); err != nil {
+					return 0, err
+				}
+			}
+			if id == 0 {
+				created, err := client.SendingProfiles.CreateSendingProfileContext(ctx, &sp)
+				if err != nil {
+					return 0, err
+				}
+				return created.ID, nil
+			}
+			_, err := client.SendingProfiles.UpdateSendingProfileContext(ctx, &sp)
+			return id, err
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	templates, err := client.Templates.ListTemplatesContext(ctx)
+	if err != nil {
+		return result, err
+	}
+	imp = newImporter(manifest.KindTemplate, policy, opts.DryRun, result)
+	for _, t := range templates {
+		imp.exists(t.Name, t.ID)
+	}
+	for _, t := range a.Templates {
+		t := t
+		err := imp.add(t.Name, t.ID, func(name string, id int) (int, error) {
+			t.Name, t.ID = name, id
+			if id == 0 {
+				created, err := client.Templates.CreateTemplateContext(ctx, &t)
+				if err != nil {
+					return 0, err
+				}
+				return created.ID, nil
+			}
+			_, err := client.Templates.UpdateTemplateContext(ctx, &t)
+			return id, err
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	pages, err := client.LandingPages.ListLandingPagesContext(ctx)
+	if err != nil {
+		return result, err
+	}
+	imp = newImporter(manifest.KindLandingPage, policy, opts.DryRun, result)
+	for _, lp := range pages {
+		imp.exists(lp.Name, lp.ID)
+	}
+	for _, lp := range a.LandingPages {
+		lp := lp
+		err := imp.add(lp.Name, lp.ID, func(name string, id int) (int, error) {
+			lp.Name, lp.ID = name, id
+			if id == 0 {
+				created, err := client.LandingPages.CreateLandingPageContext(ctx, &lp)
+				if err != nil {
+					return 0, err
+				}
+				return created.ID, nil
+			}
+			_, err := client.LandingPages.UpdateLandingPageContext(ctx, &lp)
+			return id, err
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	groups, err := client.Groups.ListGroupSummariesContext(ctx)
+	if err != nil {
+		return result, err
+	}
+	imp = newImporter(manifest.KindGroup, policy, opts.DryRun, result)
+	for _, g := range groups {
+		imp.exists(g.Name, g.ID)
+	}
+	for _, g := range a.Groups {
+		g := g
+		err := imp.add(g.Name, g.ID, func(name string, id int) (int, error) {
+			g.Name, g.ID = name, id
+			if id == 0 {
+				created, err := client.Groups.CreateGroupContext(ctx, &g)
+				if err != nil {
+					return 0, err
+				}
+				return created.ID, nil
+			}
+			_, err := client.Groups.UpdateGroupContext(ctx, &g)
+			return id, err
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	for _, c := range a.Campaigns {
+		result.Resources = append(result.Resources, ImportedResource{
+			Kind:    manifest.KindCampaign,
+			Name:    c.Name,
+			OldID:   c.ID,
+			Outcome: OutcomeSkipped,
+			Reason:  "campaigns can't be restored, their results are kept in the archive",
+		})
+	}
+	return result, nil
+}
+
+// importer imports the resources of one kind, applying the collision
+// policy.
+type importer struct {
+	kind   string
+	policy CollisionPolicy
+	dryRun bool
+	result *ImportResult
+
+	// ids are the IDs of the resources of the kind in the instance, by
+	// name, including those imported so far.
+	ids map[string]int
+}
+
+func newImporter(kind string, policy CollisionPolicy, dryRun bool, result *ImportResult) *importer {
+	return &importer{
+		kind:   kind,
+		policy: policy,
+		dryRun: dryRun,
+		result: result,
+		ids:    make(map[string]int),
+	}
+}
+
+func (imp *importer) exists(name string, id int) {
+	imp.ids[name] = id
+}
+
+// add imports a resource with save, which creates it under the given name
+// if id is 0 or overwrites the resource with that ID otherwise, returning
+// the ID it's saved as.
+func (imp *importer) add(name string, oldID int, save func(name string, id int) (int, error)) error {
+	res := ImportedResource{Kind: imp.kind, Name: name, OldID: oldID, Outcome: OutcomeCreated}
+	saveAs, overwrite := name, 0
+
+	if existing, ok := imp.ids[name]; ok {
+		switch imp.policy {
+		case Skip:
+			res.Outcome = OutcomeSkipped
+			res.NewID = existing
+			res.Reason = "already exists"
+			imp.result.Resources = append(imp.result.Resources, res)
+			return nil
+		case Rename:
+			res.Outcome = OutcomeRenamed
+			saveAs = imp.freeName(name)
+			res.NewName = saveAs
+		case Overwrite:
+			res.Outcome = OutcomeOverwritten
+			overwrite = existing
+			res.NewID = existing
+		}
+	}
+
+	if !imp.dryRun {
+		id, err := save(saveAs, overwrite)
+		if err != nil {
+			return fmt.Errorf("backup: importing %s %q: %v", imp.kind, name, err)
+		}
+		res.NewID = id
+	}
+	// Mark the name as taken even on dry runs, so later resources are
+	// renamed as they would be.
+	imp.ids[saveAs] = res.NewID
+	imp.result.Resources = append(imp.result.Resources, res)
+	return nil
+}
+
+// freeName returns the first of "name (2)", "name (3)"... that isn't taken.
+func (imp *importer) freeName(name string) string {
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)", name, i)
+		if _, taken := imp.ids[candidate]; !taken {
+			return candidate
+		}
+	}
+}
@@ -1,24 +1,26 @@
 package gophish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 // Campaign is our phishing campaign against a given group of recipients.
 type Campaign struct {
 	ID            int              `json:"id"`
 	Name          string           `json:"name"`
-	CreatedDate   string           `json:"created_date"`
-	LaunchDate    string           `json:"launch_date"`
-	SendByDate    string           `json:"send_by_date"`
-	CompletedDate string           `json:"completed_date"`
+	CreatedDate   Time             `json:"created_date"`
+	LaunchDate    Time             `json:"launch_date"`
+	SendByDate    Time             `json:"send_by_date"`
+	CompletedDate Time             `json:"completed_date"`
 	Template      Template         `json:"template"`
 	Page          LandingPage      `json:"page"`
-	Status        string           `json:"status"`
+	Status        CampaignStatus   `json:"status"`
 	Stats         CampaignStats    `json:"stats"`
-	Results       []CampaignResult `json:"result"`
+	Results       []CampaignResult `json:"results"`
 	Groups        []Group          `json:"groups"`
 	Timeline      []CampaignEvent  `json:"timeline"`
 	SMTP          SendingProfile   `json:"smtp"`
@@ -44,25 +46,26 @@ type CampaignStats struct {
 //  - Recipients entering credentials into phishing sites
 //  - Recipients reporting phishing emails
 type CampaignEvent struct {
-	Email   string `json:"email"`
-	Time    string `json:"time"`
-	Message string `json:"message"`
-	Details string `json:"details"`
+	Email   string       `json:"email"`
+	Time    Time         `json:"time"`
+	Message EventMessage `json:"message"`
+	Details string       `json:"details"`
 }
 
 // CampaignResult is a specific result for a given recipient in a given
 // campaign.
 type CampaignResult struct {
-	ID        string  `json:"id"`
-	FirstName string  `json:"first_name"`
-	LastName  string  `json:"last_name"`
-	Position  string  `json:"position"`
-	Status    string  `json:"status"`
-	IP        string  `json:"ip"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	SendDate  string  `json:"send_date"`
-	Reported  bool    `json:"reported"`
+	ID        string       `json:"id"`
+	Email     string       `json:"email"`
+	FirstName string       `json:"first_name"`
+	LastName  string       `json:"last_name"`
+	Position  string       `json:"position"`
+	Status    ResultStatus `json:"status"`
+	IP        string       `json:"ip"`
+	Latitude  float64      `json:"latitude"`
+	Longitude float64      `json:"longitude"`
+	SendDate  Time         `json:"send_date"`
+	Reported  bool         `json:"reported"`
 }
 
 // CampaignsService is how we access and manipulate /campaigns.
@@ -72,10 +75,16 @@ type CampaignsService struct {
 
 // ListCampaigns retrieves a list of campaigns.
 func (ss *CampaignsService) ListCampaigns() ([]Campaign, error) {
-	resp, err := ss.MakeRequest("GET", "/api/campaigns", nil)
+	return ss.ListCampaignsContext(context.Background())
+}
+
+// ListCampaignsContext is like ListCampaigns, but uses the given context.
+func (ss *CampaignsService) ListCampaignsContext(ctx context.Context) ([]Campaign, error) {
+	resp, err := ss.MakeRequestContext(ctx, "GET", "/api/campaigns", nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var campaigns []Campaign
 	if err := json.NewDecoder(resp.Body).Decode(&campaigns); err != nil {
@@ -84,9 +93,30 @@ func (ss *CampaignsService) ListCampaigns() ([]Campaign, error) {
 	return campaigns, nil
 }
 
+// StreamCampaigns is like ListCampaigns, but returns an iterator that
+// decodes campaigns one at a time. The iterator must be closed.
+func (ss *CampaignsService) StreamCampaigns() (*CampaignIterator, error) {
+	return ss.StreamCampaignsContext(context.Background())
+}
+
+// StreamCampaignsContext is like StreamCampaigns, but uses the given context.
+func (ss *CampaignsService) StreamCampaignsContext(ctx context.Context) (*CampaignIterator, error) {
+	resp, err := ss.MakeRequestContext(ctx, "GET", "/api/campaigns", nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CampaignIterator{stream: newArrayStream(resp.Body)}, nil
+}
+
 // GetCampaign retrieves a campaign given an ID.
 func (ss *CampaignsService) GetCampaign(id int) (*Campaign, error) {
-	resp, err := ss.MakeRequest(
+	return ss.GetCampaignContext(context.Background(), id)
+}
+
+// GetCampaignContext is like GetCampaign, but uses the given context.
+func (ss *CampaignsService) GetCampaignContext(ctx context.Context, id int) (*Campaign, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("/api/campaigns/%d", id),
 		nil,
@@ -94,6 +124,7 @@ func (ss *CampaignsService) GetCampaign(id int) (*Campaign, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var campaign Campaign
 	if err := json.NewDecoder(resp.Body).Decode(&campaign); err != nil {
@@ -104,7 +135,13 @@ func (ss *CampaignsService) GetCampaign(id int) (*Campaign, error) {
 
 // GetCampaignResults retrieves the results for a campaign given and ID.
 func (ss *CampaignsService) GetCampaignResults(id int) (*Campaign, error) {
-	resp, err := ss.MakeRequest(
+	return ss.GetCampaignResultsContext(context.Background(), id)
+}
+
+// GetCampaignResultsContext is like GetCampaignResults, but uses the given context.
+func (ss *CampaignsService) GetCampaignResultsContext(ctx context.Context, id int) (*Campaign, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("/api/campaigns/%d/results", id),
 		nil,
@@ -112,6 +149,7 @@ func (ss *CampaignsService) GetCampaignResults(id int) (*Campaign, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var campaign Campaign
 	if err := json.NewDecoder(resp.Body).Decode(&campaign); err != nil {
@@ -122,7 +160,13 @@ func (ss *CampaignsService) GetCampaignResults(id int) (*Campaign, error) {
 
 // GetCampaignSummary retrieves the summer for a campaign given and ID.
 func (ss *CampaignsService) GetCampaignSummary(id int) (*Campaign, error) {
-	resp, err := ss.MakeRequest(
+	return ss.GetCampaignSummaryContext(context.Background(), id)
+}
+
+// GetCampaignSummaryContext is like GetCampaignSummary, but uses the given context.
+func (ss *CampaignsService) GetCampaignSummaryContext(ctx context.Context, id int) (*Campaign, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("/api/campaigns/%d/summary", id),
 		nil,
@@ -130,6 +174,7 @@ func (ss *CampaignsService) GetCampaignSummary(id int) (*Campaign, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var campaign Campaign
 	if err := json.NewDecoder(resp.Body).Decode(&campaign); err != nil {
@@ -140,10 +185,16 @@ func (ss *CampaignsService) GetCampaignSummary(id int) (*Campaign, error) {
 
 // CreateCampaign creates a new campaign.
 func (ss *CampaignsService) CreateCampaign(sp *Campaign) (*Campaign, error) {
-	resp, err := ss.MakeRequest("POST", "/api/campaigns", sp)
+	return ss.CreateCampaignContext(context.Background(), sp)
+}
+
+// CreateCampaignContext is like CreateCampaign, but uses the given context.
+func (ss *CampaignsService) CreateCampaignContext(ctx context.Context, sp *Campaign) (*Campaign, error) {
+	resp, err := ss.MakeRequestContext(ctx, "POST", "/api/campaigns", sp)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var campaign Campaign
 	if err := json.NewDecoder(resp.Body).Decode(&campaign); err != nil {
@@ -154,7 +205,13 @@ func (ss *CampaignsService) CreateCampaign(sp *Campaign) (*Campaign, error) {
 
 // UpdateCampaign updates the given campaign.
 func (ss *CampaignsService) UpdateCampaign(sp *Campaign) (*Campaign, error) {
-	resp, err := ss.MakeRequest(
+	return ss.UpdateCampaignContext(context.Background(), sp)
+}
+
+// UpdateCampaignContext is like UpdateCampaign, but uses the given context.
+func (ss *CampaignsService) UpdateCampaignContext(ctx context.Context, sp *Campaign) (*Campaign, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"PUT",
 		fmt.Sprintf("/api/campaigns/%d", sp.ID),
 		sp,
@@ -162,6 +219,7 @@ func (ss *CampaignsService) UpdateCampaign(sp *Campaign) (*Campaign, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var campaign Campaign
 	if err := json.NewDecoder(resp.Body).Decode(&campaign); err != nil {
@@ -172,7 +230,13 @@ func (ss *CampaignsService) UpdateCampaign(sp *Campaign) (*Campaign, error) {
 
 // DeleteCampaign deletes a campaign given an ID.
 func (ss *CampaignsService) DeleteCampaign(id int) (bool, error) {
-	resp, err := ss.MakeRequest(
+	return ss.DeleteCampaignContext(context.Background(), id)
+}
+
+// DeleteCampaignContext is like DeleteCampaign, but uses the given context.
+func (ss *CampaignsService) DeleteCampaignContext(ctx context.Context, id int) (bool, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"DELETE",
 		fmt.Sprintf("/api/campaigns/%d", id),
 		nil,
@@ -180,20 +244,71 @@ func (ss *CampaignsService) DeleteCampaign(id int) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	defer resp.Body.Close()
 
 	return resp.StatusCode == http.StatusOK, nil
 }
 
-// CompleteCampaign marks a campaign as completed given an ID.
-func (ss *CampaignsService) CompleteCampaign(id int) (bool, error) {
-	resp, err := ss.MakeRequest(
-		"GET",
-		fmt.Sprintf("/api/campaigns/%d", id),
-		nil,
-	)
+// CompleteCampaign marks a campaign as completed given an ID, returning
+// gophish's response along with the updated campaign.
+func (ss *CampaignsService) CompleteCampaign(id int) (*CompleteCampaignResponse, error) {
+	return ss.CompleteCampaignContext(context.Background(), id)
+}
+
+// CompleteCampaignContext is like CompleteCampaign, but uses the given context.
+func (ss *CampaignsService) CompleteCampaignContext(ctx context.Context, id int) (*CompleteCampaignResponse, error) {
+	path := fmt.Sprintf("/api/campaigns/%d/complete", id)
+	resp, err := ss.MakeRequestContext(ctx, "GET", path, nil)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	return resp.StatusCode == http.StatusOK, nil
+	var completion CompleteCampaignResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return nil, err
+	}
+	if !completion.Success {
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Method:     "GET",
+			Path:       path,
+			Success:    completion.Success,
+			Message:    completion.Message,
+		}
+	}
+
+	campaign, err := ss.GetCampaignContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	completion.Campaign = campaign
+	return &completion, nil
+}
+
+// CompleteCampaignResponse is gophish's response to completing a campaign.
+type CompleteCampaignResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+
+	// Campaign is the campaign as it is after being completed.
+	Campaign *Campaign `json:"-"`
+}
+
+// WaitForCampaignStatus polls the summary of the campaign with the given ID
+// every interval until it has the given status (i.e. CampaignCompleted),
+// returning the last summary retrieved. It gives up once the context is done.
+func (ss *CampaignsService) WaitForCampaignStatus(ctx context.Context, id int, status CampaignStatus, interval time.Duration) (*Campaign, error) {
+	for {
+		campaign, err := ss.GetCampaignSummaryContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if campaign.Status == status {
+			return campaign, nil
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return campaign, err
+		}
+	}
 }
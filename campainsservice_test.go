@@ -0,0 +1,239 @@
+package gophish_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ttacon/gophish"
+)
+
+// newCampaign creates the resources a campaign needs, returning a campaign
+// referring to them.
+func newCampaign(t *testing.T, client *gophish.Client, name string) *gophish.Campaign {
+	t.Helper()
+
+	if _, err := client.SendingProfiles.CreateSendingProfile(&gophish.SendingProfile{
+		Name:        "SMTP " + name,
+		Host:        "smtp.example.com:587",
+		FromAddress: "it@example.com",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Templates.CreateTemplate(&gophish.Template{
+		Name: "Template " + name,
+		Text: "{{.URL}}",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.LandingPages.CreateLandingPage(&gophish.LandingPage{
+		Name: "Page " + name,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Groups.CreateGroup(&gophish.Group{
+		Name: "Group " + name,
+		Targets: []gophish.Target{
+			{Email: "jdoe@example.com"},
+			{Email: "asmith@example.com"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return &gophish.Campaign{
+		Name:     name,
+		URL:      "https://phish.example.com",
+		Template: gophish.Template{Name: "Template " + name},
+		Page:     gophish.LandingPage{Name: "Page " + name},
+		SMTP:     gophish.SendingProfile{Name: "SMTP " + name},
+		Groups:   []gophish.Group{{Name: "Group " + name}},
+	}
+}
+
+func TestCampaignLifecycle(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	created, err := client.Campaigns.CreateCampaign(newCampaign(t, client, "Q2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Status != gophish.CampaignInProgress {
+		t.Errorf("got status %q, want %q", created.Status, gophish.CampaignInProgress)
+	}
+	if !created.CreatedDate.Equal(now) || !created.LaunchDate.Equal(now) {
+		t.Errorf("got dates %v and %v, want %v", created.CreatedDate, created.LaunchDate, now)
+	}
+	if created.SMTP.Host != "smtp.example.com:587" || len(created.Groups[0].Targets) != 2 {
+		t.Errorf("references weren't resolved: %+v", created)
+	}
+	if created.Stats.Total != 2 || created.Stats.Sent != 2 {
+		t.Errorf("got stats %+v, want 2 emails sent", created.Stats)
+	}
+
+	if err := srv.RecordEvent(created.ID, "jdoe@example.com", gophish.EventClickedLink); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := client.Campaigns.GetCampaignResults(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clicked := 0
+	for _, r := range results.Results {
+		if r.HasClicked() {
+			clicked++
+		}
+	}
+	if clicked != 1 {
+		t.Errorf("got %d recipients who clicked, want 1", clicked)
+	}
+	last := results.Timeline[len(results.Timeline)-1]
+	if last.Email != "jdoe@example.com" || last.Message != gophish.EventClickedLink {
+		t.Errorf("got last event %+v, want jdoe clicking", last)
+	}
+
+	summary, err := client.Campaigns.GetCampaignSummary(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Stats.Clicked != 1 || summary.Stats.Opened != 1 {
+		t.Errorf("got stats %+v, want 1 open and click", summary.Stats)
+	}
+
+	list, err := client.Campaigns.ListCampaigns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].ID != created.ID {
+		t.Errorf("got %+v, want the created campaign", list)
+	}
+
+	if ok, err := client.Campaigns.DeleteCampaign(created.ID); err != nil || !ok {
+		t.Fatalf("got %v, %v deleting the campaign", ok, err)
+	}
+	if _, err := client.Campaigns.GetCampaign(created.ID); !gophish.IsNotFound(err) {
+		t.Errorf("got error %v after deleting the campaign, want not found", err)
+	}
+}
+
+func TestCreateCampaignValidation(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	valid := newCampaign(t, client, "Q3")
+	tests := []struct {
+		name   string
+		modify func(*gophish.Campaign)
+	}{
+		{"no name", func(c *gophish.Campaign) { c.Name = "" }},
+		{"no groups", func(c *gophish.Campaign) { c.Groups = nil }},
+		{"unknown group", func(c *gophish.Campaign) { c.Groups = []gophish.Group{{Name: "Nope"}} }},
+		{"unknown template", func(c *gophish.Campaign) { c.Template.Name = "Nope" }},
+		{"unknown page", func(c *gophish.Campaign) { c.Page.Name = "Nope" }},
+		{"unknown sending profile", func(c *gophish.Campaign) { c.SMTP.Name = "Nope" }},
+	}
+	for _, tt := range tests {
+		c := *valid
+		c.Groups = append([]gophish.Group(nil), valid.Groups...)
+		tt.modify(&c)
+		if _, err := client.Campaigns.CreateCampaign(&c); !gophish.IsBadRequest(err) {
+			t.Errorf("%s: got error %v, want bad request", tt.name, err)
+		}
+	}
+}
+
+func TestCompleteCampaign(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	campaign := newCampaign(t, client, "Q4")
+	campaign.LaunchDate = gophish.NewTime(now.Add(time.Hour))
+	created, err := client.Campaigns.CreateCampaign(campaign)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Status != gophish.CampaignQueued {
+		t.Errorf("got status %q for a future campaign, want %q", created.Status, gophish.CampaignQueued)
+	}
+
+	completion, err := client.Campaigns.CompleteCampaign(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !completion.Success || completion.Message == "" {
+		t.Errorf("got %+v, want gophish's success message", completion)
+	}
+	if completion.Campaign == nil ||
+		completion.Campaign.Status != gophish.CampaignCompleted ||
+		!completion.Campaign.CompletedDate.Equal(now) {
+		t.Errorf("got campaign %+v, want it completed", completion.Campaign)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	summary, err := client.Campaigns.WaitForCampaignStatus(ctx, created.ID, gophish.CampaignCompleted, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Status != gophish.CampaignCompleted {
+		t.Errorf("got status %q", summary.Status)
+	}
+
+	if _, err := client.Campaigns.CompleteCampaign(created.ID + 100); !gophish.IsNotFound(err) {
+		t.Errorf("got error %v completing a missing campaign, want not found", err)
+	}
+}
+
+func TestWaitForCampaignStatusGivesUp(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	created, err := client.Campaigns.CreateCampaign(newCampaign(t, client, "Q1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	summary, err := client.Campaigns.WaitForCampaignStatus(ctx, created.ID, gophish.CampaignCompleted, 5*time.Millisecond)
+	if err != context.DeadlineExceeded {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if summary == nil || summary.Status != gophish.CampaignInProgress {
+		t.Errorf("got %+v, want the last summary", summary)
+	}
+}
+
+func TestStreamCampaigns(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	for _, name := range []string{"Q1", "Q2"} {
+		if _, err := client.Campaigns.CreateCampaign(newCampaign(t, client, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	it, err := client.Campaigns.StreamCampaigns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	var names []string
+	for it.Next() {
+		c := it.Campaign()
+		if len(c.Results) != 2 {
+			t.Errorf("%s: got %d results, want 2", c.Name, len(c.Results))
+		}
+		names = append(names, c.Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || names[0] != "Q1" || names[1] != "Q2" {
+		t.Errorf("got campaigns %v, want Q1 and Q2", names)
+	}
+}
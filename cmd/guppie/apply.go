@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ttacon/gophish/manifest"
+	"github.com/urfave/cli"
+)
+
+// applyCommand is `apply`.
+func applyCommand() cli.Command {
+	return cli.Command{
+		Name:  "apply",
+		Usage: "Make gophish match YAML manifests of resources",
+		Flags: []cli.Flag{
+			cli.StringSliceFlag{
+				Name:  "file, f",
+				Usage: "A manifest file, or a directory of them (repeatable)",
+			},
+			cli.BoolFlag{
+				Name:  "prune",
+				Usage: "Delete resources of the kinds in the manifests that aren't in them (except campaigns)",
+			},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Only show the plan",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			paths := c.StringSlice("file")
+			if len(paths) == 0 {
+				return errors.New("no manifests given, use --file")
+			}
+			m, err := manifest.Load(paths...)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			client := newClient(c)
+			plan, err := manifest.NewPlan(ctx, client, m, manifest.PlanOptions{
+				Prune: c.Bool("prune"),
+			})
+			if err != nil {
+				return err
+			}
+			if structuredOutput(c) {
+				if err := printResult(c, plan); err != nil {
+					return err
+				}
+			} else {
+				info(c, "%s", plan)
+			}
+
+			if c.Bool("dry-run") || plan.Empty() {
+				return nil
+			}
+			if err := plan.Apply(ctx, client); err != nil {
+				return err
+			}
+			info(c, "Applied.\n")
+			return nil
+		},
+	}
+}
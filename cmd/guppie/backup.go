@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/ttacon/gophish/backup"
+	"github.com/urfave/cli"
+)
+
+// exportCommand is `export`.
+func exportCommand() cli.Command {
+	return cli.Command{
+		Name:  "export",
+		Usage: "Back up every resource and campaign result to an archive",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "file, f",
+				Usage: "The archive to write, i.e. gophish-backup.json.gz",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			path := c.String("file")
+			if path == "" {
+				return errors.New("no archive given, use --file")
+			}
+
+			client := newClient(c)
+			archive, err := backup.Export(context.Background(), client)
+			if err != nil {
+				return err
+			}
+
+			// Archives hold sending profile passwords.
+			f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			if err := archive.Write(f); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			info(c, "Exported %d sending profiles, %d templates, %d landing pages, %d groups and %d campaigns to %s\n",
+				len(archive.SendingProfiles), len(archive.Templates), len(archive.LandingPages),
+				len(archive.Groups), len(archive.Campaigns), path)
+			return nil
+		},
+	}
+}
+
+// importCommand is `import`.
+func importCommand() cli.Command {
+	return cli.Command{
+		Name:  "import",
+		Usage: "Restore the resources of an archive made by export",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "file, f",
+				Usage: "The archive to restore",
+			},
+			cli.StringFlag{
+				Name:  "on-collision",
+				Usage: "What to do with resources whose names are taken: skip, rename or overwrite",
+				Value: string(backup.Skip),
+			},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Only show what would be imported",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			policy, err := backup.ParseCollisionPolicy(c.String("on-collision"))
+			if err != nil {
+				return err
+			}
+			f, err := os.Open(c.String("file"))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			archive, err := backup.Read(f)
+			if err != nil {
+				return err
+			}
+
+			client := newClient(c)
+			result, err := backup.Import(context.Background(), client, archive, backup.ImportOptions{
+				OnCollision: policy,
+				DryRun:      c.Bool("dry-run"),
+			})
+			if result != nil {
+				if structuredOutput(c) {
+					if err := printResult(c, result.Resources); err != nil {
+						return err
+					}
+				} else {
+					info(c, "%s", result)
+				}
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"github.com/ttacon/gophish"
+	"github.com/urfave/cli"
+)
+
+// newClient creates a gophish client from the global flags, falling back to
+// the profile from the config file or, if no profile is selected, to the
+// GOPHISH_HOST and GOPHISH_TOKEN environment variables. Like
+// gophish.NewClient, errors are returned by the client's requests.
+func newClient(c *cli.Context) *gophish.Client {
+	p, err := currentProfile(c)
+	if err != nil {
+		return gophish.NewClient("", "", failWith(err))
+	}
+	if p == nil {
+		p = &profile{
+			Host:  os.Getenv("GOPHISH_HOST"),
+			Token: os.Getenv("GOPHISH_TOKEN"),
+		}
+	}
+
+	host := c.GlobalString("host")
+	if host == "" {
+		host = p.Host
+	}
+	token := c.GlobalString("token")
+	if token == "" {
+		if token, err = p.token(); err != nil {
+			return gophish.NewClient("", "", failWith(err))
+		}
+	}
+	return gophish.NewClient(host, token, clientOptions(c, p)...)
+}
+
+// profileClient creates a gophish client for the named profile, regardless
+// of --host, --token and --profile.
+func profileClient(c *cli.Context, name string) *gophish.Client {
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return gophish.NewClient("", "", failWith(err))
+	}
+	p, err := cfg.profile(name)
+	if err != nil {
+		return gophish.NewClient("", "", failWith(err))
+	}
+	token, err := p.token()
+	if err != nil {
+		return gophish.NewClient("", "", failWith(err))
+	}
+	return gophish.NewClient(p.Host, token, clientOptions(c, p)...)
+}
+
+// failWith is an option failing with the given error.
+func failWith(err error) gophish.Option {
+	return func(*gophish.Service) error {
+		return err
+	}
+}
+
+func clientOptions(c *cli.Context, p *profile) []gophish.Option {
+	var opts []gophish.Option
+	caFile := c.GlobalString("ca-cert")
+	if caFile == "" {
+		caFile = p.CACert
+	}
+	if caFile != "" {
+		opts = append(opts, withCAFile(caFile))
+	}
+	if c.GlobalBool("insecure") || p.Insecure {
+		opts = append(opts, gophish.WithInsecureSkipVerify())
+	}
+	if timeout := c.GlobalDuration("timeout"); timeout > 0 {
+		opts = append(opts, gophish.WithTimeout(timeout))
+	}
+	if retries := c.GlobalInt("retries"); retries > 0 {
+		policy := gophish.DefaultRetryPolicy
+		policy.MaxRetries = retries
+		opts = append(opts, gophish.WithRetryPolicy(policy))
+	}
+	if c.GlobalBool("debug") {
+		opts = append(opts, gophish.WithMiddleware(
+			gophish.DebugMiddleware(os.Stderr),
+		))
+	}
+	return opts
+}
+
+// withCAFile trusts the certificate authorities in the given PEM file.
+func withCAFile(path string) gophish.Option {
+	return func(s *gophish.Service) error {
+		path, err := expandHome(path)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return errors.New("no certificates found in " + path)
+		}
+		return gophish.WithRootCAs(pool)(s)
+	}
+}
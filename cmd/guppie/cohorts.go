@@ -0,0 +1,118 @@
+package main
+
+import (
+	"time"
+
+	"github.com/ttacon/gophish"
+	"github.com/urfave/cli"
+)
+
+// seed returns the --seed flag, or a random seed if it isn't set. It's
+// printed either way so the split or sample can be reproduced.
+func seed(c *cli.Context) int64 {
+	s := c.Int64("seed")
+	if !c.IsSet("seed") {
+		s = time.Now().UnixNano()
+	}
+	info(c, "Seed: %d\n", s)
+	return s
+}
+
+// splitCommand is `groups split`.
+func splitCommand() cli.Command {
+	return cli.Command{
+		Name:  "split",
+		Usage: "Randomly split a group into balanced cohorts, creating a group for each",
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "group-id",
+				Usage: "The ID of the group to split",
+			},
+			cli.IntFlag{
+				Name:  "cohorts",
+				Usage: "The number of cohorts to split the group into",
+				Value: 2,
+			},
+			cli.BoolFlag{
+				Name:  "stratify",
+				Usage: "Spread each position evenly across the cohorts",
+			},
+			cli.Int64Flag{
+				Name:  "seed",
+				Usage: "Seed the split to make it reproducible",
+			},
+			cli.StringFlag{
+				Name:  "name-format",
+				Usage: "Name the cohorts with this format, given the group's name and cohort number",
+				Value: "%s - Cohort %d",
+			},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Only show the cohorts",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			client := newClient(c)
+			groups, err := client.Groups.SplitGroup(
+				c.Int("group-id"),
+				c.Int("cohorts"),
+				gophish.SplitOptions{
+					Seed:               seed(c),
+					StratifyByPosition: c.Bool("stratify"),
+					NameFormat:         c.String("name-format"),
+					DryRun:             c.Bool("dry-run"),
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return printResult(c, groups)
+		},
+	}
+}
+
+// sampleCommand is `groups sample`.
+func sampleCommand() cli.Command {
+	return cli.Command{
+		Name:  "sample",
+		Usage: "Create a group from a random sample of a group's targets",
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "group-id",
+				Usage: "The ID of the group to sample",
+			},
+			cli.IntFlag{
+				Name:  "size",
+				Usage: "The number of targets to sample",
+			},
+			cli.Int64Flag{
+				Name:  "seed",
+				Usage: "Seed the sample to make it reproducible",
+			},
+			cli.StringFlag{
+				Name:  "name",
+				Usage: "The name of the sample group, defaults to the group's name with \" - Sample\"",
+			},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Only show the sample",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			client := newClient(c)
+			group, err := client.Groups.SampleGroup(
+				c.Int("group-id"),
+				c.Int("size"),
+				gophish.SampleOptions{
+					Seed:   seed(c),
+					Name:   c.String("name"),
+					DryRun: c.Bool("dry-run"),
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return printResult(c, group)
+		},
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+// config is guppie's configuration file, which holds named profiles of
+// gophish instances to connect to.
+type config struct {
+	CurrentProfile string              `yaml:"current-profile,omitempty"`
+	Profiles       map[string]*profile `yaml:"profiles,omitempty"`
+}
+
+// profile is how to connect to a gophish instance. The token can be given
+// directly, read from a file, or printed by a command (i.e. a password
+// manager), so it needn't be stored in the config file.
+type profile struct {
+	Host         string `yaml:"host,omitempty"`
+	Token        string `yaml:"token,omitempty"`
+	TokenFile    string `yaml:"token-file,omitempty"`
+	TokenCommand string `yaml:"token-command,omitempty"`
+	Insecure     bool   `yaml:"insecure,omitempty"`
+	CACert       string `yaml:"ca-cert,omitempty"`
+}
+
+// configFlags are the global flags selecting the config file and profile.
+func configFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   "config",
+			Usage:  "The config file (default: ~/.config/guppie/config.yaml)",
+			EnvVar: "GUPPIE_CONFIG",
+		},
+		cli.StringFlag{
+			Name:   "profile",
+			Usage:  "The profile of the config file to use, instead of the current one",
+			EnvVar: "GUPPIE_PROFILE",
+		},
+	}
+}
+
+func configPath(c *cli.Context) (string, error) {
+	if path := c.GlobalString("config"); path != "" {
+		return expandHome(path)
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "guppie", "config.yaml"), nil
+}
+
+// loadConfig reads the config file, which is empty if it doesn't exist.
+func loadConfig(c *cli.Context) (*config, error) {
+	path, err := configPath(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &config{Profiles: make(map[string]*profile)}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]*profile)
+	}
+	return cfg, nil
+}
+
+// saveConfig writes the config file, readable only by the user as it may
+// hold tokens.
+func saveConfig(c *cli.Context, cfg *config) error {
+	path, err := configPath(c)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// currentProfile returns the profile given by --profile, or the config's
+// current profile. It's nil if neither is set.
+func currentProfile(c *cli.Context) (*profile, error) {
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	name := c.GlobalString("profile")
+	if name == "" {
+		name = cfg.CurrentProfile
+	}
+	if name == "" {
+		return nil, nil
+	}
+	return cfg.profile(name)
+}
+
+func (cfg *config) profile(name string) (*profile, error) {
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("no profile named %q in the config file", name)
+	}
+	return p, nil
+}
+
+// token returns the profile's token, reading its token file or running its
+// token command if needed.
+func (p *profile) token() (string, error) {
+	switch {
+	case p.Token != "":
+		return p.Token, nil
+	case p.TokenFile != "":
+		path, err := expandHome(p.TokenFile)
+		if err != nil {
+			return "", err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	case p.TokenCommand != "":
+		cmd := exec.Command("sh", "-c", p.TokenCommand)
+		if runtime.GOOS == "windows" {
+			cmd = exec.Command("cmd", "/C", p.TokenCommand)
+		}
+		cmd.Stderr = os.Stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("running token command: %v", err)
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+	return "", nil
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, path[1:]), nil
+}
+
+// profileFlags are the settings of `config set`.
+func profileFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "host",
+			Usage: "The gophish host",
+		},
+		cli.StringFlag{
+			Name:  "token",
+			Usage: "The API token, stored in the config file",
+		},
+		cli.StringFlag{
+			Name:  "token-file",
+			Usage: "Read the API token from this file",
+		},
+		cli.StringFlag{
+			Name:  "token-command",
+			Usage: "Run this command for the API token, i.e. \"pass show gophish\"",
+		},
+		cli.BoolFlag{
+			Name:  "insecure",
+			Usage: "Skip verification of the gophish TLS certificate",
+		},
+		cli.StringFlag{
+			Name:  "ca-cert",
+			Usage: "A PEM file of certificate authorities to trust",
+		},
+		cli.BoolFlag{
+			Name:  "use",
+			Usage: "Also make this the current profile",
+		},
+	}
+}
+
+// profileSummary is a profile as listed by `config list`, without secrets.
+type profileSummary struct {
+	Name    string
+	Current bool
+	Host    string
+	Token   string
+}
+
+func configCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:  "list",
+			Usage: "List the profiles in the config file",
+			Action: func(c *cli.Context) error {
+				cfg, err := loadConfig(c)
+				if err != nil {
+					return err
+				}
+				names := make([]string, 0, len(cfg.Profiles))
+				for name := range cfg.Profiles {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+
+				summaries := make([]profileSummary, 0, len(names))
+				for _, name := range names {
+					p := cfg.Profiles[name]
+					summary := profileSummary{
+						Name:    name,
+						Current: name == cfg.CurrentProfile,
+						Host:    p.Host,
+					}
+					switch {
+					case p.Token != "":
+						summary.Token = "(stored)"
+					case p.TokenFile != "":
+						summary.Token = "file " + p.TokenFile
+					case p.TokenCommand != "":
+						summary.Token = "command " + p.TokenCommand
+					}
+					summaries = append(summaries, summary)
+				}
+				return printResult(c, summaries)
+			},
+		},
+		{
+			Name:      "use",
+			Usage:     "Make a profile the current one",
+			ArgsUsage: "PROFILE",
+			Action: func(c *cli.Context) error {
+				name := c.Args().First()
+				cfg, err := loadConfig(c)
+				if err != nil {
+					return err
+				}
+				if _, err := cfg.profile(name); err != nil {
+					return err
+				}
+				cfg.CurrentProfile = name
+				if err := saveConfig(c, cfg); err != nil {
+					return err
+				}
+				info(c, "Using profile %q\n", name)
+				return nil
+			},
+		},
+		{
+			Name:      "set",
+			Usage:     "Create or change a profile",
+			ArgsUsage: "PROFILE",
+			Flags:     profileFlags(),
+			Action: func(c *cli.Context) error {
+				name := c.Args().First()
+				if name == "" {
+					return errors.New("a profile name is required")
+				}
+				cfg, err := loadConfig(c)
+				if err != nil {
+					return err
+				}
+				p, ok := cfg.Profiles[name]
+				if !ok {
+					p = &profile{}
+					cfg.Profiles[name] = p
+				}
+
+				setString(c, "host", &p.Host)
+				setBool(c, "insecure", &p.Insecure)
+				setString(c, "ca-cert", &p.CACert)
+				// Only one source of the token is kept, so setting one
+				// replaces the others.
+				for _, flag := range []string{"token", "token-file", "token-command"} {
+					if c.IsSet(flag) {
+						p.Token, p.TokenFile, p.TokenCommand = "", "", ""
+					}
+				}
+				setString(c, "token", &p.Token)
+				setString(c, "token-file", &p.TokenFile)
+				setString(c, "token-command", &p.TokenCommand)
+
+				if c.Bool("use") || cfg.CurrentProfile == "" {
+					cfg.CurrentProfile = name
+				}
+				return saveConfig(c, cfg)
+			},
+		},
+	}
+}
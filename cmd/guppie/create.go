@@ -0,0 +1,359 @@
+package main
+
+import (
+	"github.com/ttacon/gophish"
+	"github.com/urfave/cli"
+)
+
+func sendingProfileFlags() []cli.Flag {
+	return []cli.Flag{
+		fileFlag,
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "The name of the sending profile",
+		},
+		cli.StringFlag{
+			Name:  "smtp-host",
+			Usage: "The SMTP server, i.e. smtp.example.com:587",
+		},
+		cli.StringFlag{
+			Name:  "from-address",
+			Usage: "The address emails are sent from, i.e. \"IT <it@example.com>\"",
+		},
+		cli.StringFlag{
+			Name:  "username",
+			Usage: "The SMTP username",
+		},
+		cli.StringFlag{
+			Name:   "password",
+			Usage:  "The SMTP password",
+			EnvVar: "GUPPIE_SMTP_PASSWORD",
+		},
+		cli.BoolFlag{
+			Name:  "ignore-cert-errors",
+			Usage: "Don't verify the SMTP server's certificate",
+		},
+		cli.StringSliceFlag{
+			Name:  "header",
+			Usage: "Add a header to sent emails, i.e. --header \"X-Mailer: Outlook\" (repeatable)",
+		},
+	}
+}
+
+func applySendingProfileFlags(c *cli.Context, sp *gophish.SendingProfile) error {
+	setString(c, "name", &sp.Name)
+	setString(c, "smtp-host", &sp.Host)
+	setString(c, "from-address", &sp.FromAddress)
+	setString(c, "username", &sp.Username)
+	setString(c, "password", &sp.Password)
+	setBool(c, "ignore-cert-errors", &sp.IgnoreCertErrors)
+	if c.IsSet("header") {
+		headers, err := parseHeaders(c.StringSlice("header"))
+		if err != nil {
+			return err
+		}
+		sp.Headers = headers
+	}
+	if sp.InterfaceType == "" {
+		sp.InterfaceType = "SMTP"
+	}
+	return nil
+}
+
+func createSendingProfileCommand() cli.Command {
+	return cli.Command{
+		Name:  "create",
+		Usage: "Create a sending profile from a file and/or flags",
+		Flags: sendingProfileFlags(),
+		Action: func(c *cli.Context) error {
+			sp := &gophish.SendingProfile{}
+			if err := readObject(c, sp); err != nil {
+				return err
+			}
+			if err := applySendingProfileFlags(c, sp); err != nil {
+				return err
+			}
+			if err := sp.Validate(); err != nil {
+				return err
+			}
+
+			client := newClient(c)
+			created, err := client.SendingProfiles.CreateSendingProfile(sp)
+			if err != nil {
+				return err
+			}
+			return printResult(c, created)
+		},
+	}
+}
+
+func templateFlags() []cli.Flag {
+	return []cli.Flag{
+		fileFlag,
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "The name of the template",
+		},
+		cli.StringFlag{
+			Name:  "subject",
+			Usage: "The subject of emails",
+		},
+		cli.StringFlag{
+			Name:  "text",
+			Usage: "The plain text body of emails",
+		},
+		cli.StringFlag{
+			Name:  "text-file",
+			Usage: "Read the plain text body of emails from this file",
+		},
+		cli.StringFlag{
+			Name:  "html",
+			Usage: "The HTML body of emails",
+		},
+		cli.StringFlag{
+			Name:  "html-file",
+			Usage: "Read the HTML body of emails from this file",
+		},
+		cli.StringSliceFlag{
+			Name:  "attachment",
+			Usage: "Attach this file to emails (repeatable)",
+		},
+	}
+}
+
+func applyTemplateFlags(c *cli.Context, t *gophish.Template) error {
+	setString(c, "name", &t.Name)
+	setString(c, "subject", &t.Subject)
+	if err := setContent(c, "text", &t.Text); err != nil {
+		return err
+	}
+	if err := setContent(c, "html", &t.HTML); err != nil {
+		return err
+	}
+	if c.IsSet("attachment") {
+		attachments, err := readAttachments(c.StringSlice("attachment"))
+		if err != nil {
+			return err
+		}
+		t.Attachments = attachments
+	}
+	return nil
+}
+
+func createTemplateCommand() cli.Command {
+	return cli.Command{
+		Name:  "create",
+		Usage: "Create a template from a file and/or flags",
+		Flags: templateFlags(),
+		Action: func(c *cli.Context) error {
+			t := &gophish.Template{}
+			if err := readObject(c, t); err != nil {
+				return err
+			}
+			if err := applyTemplateFlags(c, t); err != nil {
+				return err
+			}
+			if err := t.Validate(); err != nil {
+				return err
+			}
+
+			client := newClient(c)
+			created, err := client.Templates.CreateTemplate(t)
+			if err != nil {
+				return err
+			}
+			return printResult(c, created)
+		},
+	}
+}
+
+func landingPageFlags() []cli.Flag {
+	return []cli.Flag{
+		fileFlag,
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "The name of the landing page",
+		},
+		cli.StringFlag{
+			Name:  "html",
+			Usage: "The HTML of the page",
+		},
+		cli.StringFlag{
+			Name:  "html-file",
+			Usage: "Read the HTML of the page from this file",
+		},
+		cli.BoolFlag{
+			Name:  "capture-credentials",
+			Usage: "Capture data submitted to the page",
+		},
+		cli.BoolFlag{
+			Name:  "capture-passwords",
+			Usage: "Capture submitted passwords too",
+		},
+		cli.StringFlag{
+			Name:  "redirect-url",
+			Usage: "Redirect to this URL once data is submitted",
+		},
+	}
+}
+
+func applyLandingPageFlags(c *cli.Context, p *gophish.LandingPage) error {
+	setString(c, "name", &p.Name)
+	if err := setContent(c, "html", &p.HTML); err != nil {
+		return err
+	}
+	setBool(c, "capture-credentials", &p.CaptureCredentials)
+	setBool(c, "capture-passwords", &p.CapturePasswords)
+	setString(c, "redirect-url", &p.RedirectURL)
+	return nil
+}
+
+func createLandingPageCommand() cli.Command {
+	return cli.Command{
+		Name:  "create",
+		Usage: "Create a landing page from a file and/or flags",
+		Flags: landingPageFlags(),
+		Action: func(c *cli.Context) error {
+			p := &gophish.LandingPage{}
+			if err := readObject(c, p); err != nil {
+				return err
+			}
+			if err := applyLandingPageFlags(c, p); err != nil {
+				return err
+			}
+			if err := p.Validate(); err != nil {
+				return err
+			}
+
+			client := newClient(c)
+			created, err := client.LandingPages.CreateLandingPage(p)
+			if err != nil {
+				return err
+			}
+			return printResult(c, created)
+		},
+	}
+}
+
+func groupFlags() []cli.Flag {
+	return []cli.Flag{
+		fileFlag,
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "The name of the group",
+		},
+		cli.StringSliceFlag{
+			Name:  "target",
+			Usage: "A target as \"email[,first name[,last name[,position]]]\" (repeatable)",
+		},
+	}
+}
+
+func applyGroupFlags(c *cli.Context, g *gophish.Group) {
+	setString(c, "name", &g.Name)
+	if c.IsSet("target") {
+		g.Targets = parseTargets(c.StringSlice("target"))
+	}
+}
+
+func createGroupCommand() cli.Command {
+	return cli.Command{
+		Name:  "create",
+		Usage: "Create a group from a file and/or flags",
+		Flags: groupFlags(),
+		Action: func(c *cli.Context) error {
+			g := &gophish.Group{}
+			if err := readObject(c, g); err != nil {
+				return err
+			}
+			applyGroupFlags(c, g)
+			if err := g.Validate(); err != nil {
+				return err
+			}
+
+			client := newClient(c)
+			created, err := client.Groups.CreateGroup(g)
+			if err != nil {
+				return err
+			}
+			return printResult(c, created)
+		},
+	}
+}
+
+// createCampaignCommand is `campaigns create`. There's no update command as
+// gophish doesn't allow campaigns to be changed once created.
+func createCampaignCommand() cli.Command {
+	return cli.Command{
+		Name:  "create",
+		Usage: "Create (and schedule) a campaign from a file and/or flags",
+		Flags: []cli.Flag{
+			fileFlag,
+			cli.StringFlag{
+				Name:  "name",
+				Usage: "The name of the campaign",
+			},
+			cli.StringFlag{
+				Name:  "template",
+				Usage: "The name of the template to send",
+			},
+			cli.StringFlag{
+				Name:  "page",
+				Usage: "The name of the landing page",
+			},
+			cli.StringFlag{
+				Name:  "smtp",
+				Usage: "The name of the sending profile to send with",
+			},
+			cli.StringSliceFlag{
+				Name:  "group",
+				Usage: "The name of a group to send to (repeatable)",
+			},
+			cli.StringFlag{
+				Name:  "url",
+				Usage: "The URL of the gophish listener that links point to",
+			},
+			cli.StringFlag{
+				Name:  "launch-date",
+				Usage: "When to launch the campaign (RFC 3339), defaults to now",
+			},
+			cli.StringFlag{
+				Name:  "send-by-date",
+				Usage: "Spread sending emails out until this time (RFC 3339)",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			campaign := &gophish.Campaign{}
+			if err := readObject(c, campaign); err != nil {
+				return err
+			}
+			setString(c, "name", &campaign.Name)
+			setString(c, "template", &campaign.Template.Name)
+			setString(c, "page", &campaign.Page.Name)
+			setString(c, "smtp", &campaign.SMTP.Name)
+			setString(c, "url", &campaign.URL)
+			if c.IsSet("group") {
+				campaign.Groups = nil
+				for _, name := range c.StringSlice("group") {
+					campaign.Groups = append(campaign.Groups, gophish.Group{Name: name})
+				}
+			}
+			if err := setTime(c, "launch-date", &campaign.LaunchDate); err != nil {
+				return err
+			}
+			if err := setTime(c, "send-by-date", &campaign.SendByDate); err != nil {
+				return err
+			}
+			if err := campaign.Validate(); err != nil {
+				return err
+			}
+
+			client := newClient(c)
+			created, err := client.Campaigns.CreateCampaign(campaign)
+			if err != nil {
+				return err
+			}
+			return printResult(c, created)
+		},
+	}
+}
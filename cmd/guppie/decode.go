@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/manifest"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+// fileFlag is the flag create and update commands read a resource from.
+var fileFlag = cli.StringFlag{
+	Name:  "file, f",
+	Usage: "Read the resource from this JSON or YAML file (- for stdin), flags override it",
+}
+
+// readObject decodes the file given by fileFlag, if any, into v. Fields v
+// already has are kept unless the file sets them, so updates can be partial.
+func readObject(c *cli.Context, v interface{}) error {
+	path := c.String("file")
+	if path == "" {
+		return nil
+	}
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+	if err := decodeObject(path, data, v); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
+
+// decodeObject decodes JSON or, judging by the file name, YAML into v using
+// v's JSON field names. Unknown fields are an error, to catch typos. YAML is
+// a superset of JSON, so stdin ("-") is decoded as YAML.
+func decodeObject(name string, data []byte, v interface{}) error {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", "":
+		var err error
+		if data, err = yamlToJSON(data); err != nil {
+			return err
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+func yamlToJSON(data []byte) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(manifest.JSONValue(v))
+}
+
+// setString sets dst to the named flag, if it was given.
+func setString(c *cli.Context, name string, dst *string) {
+	if c.IsSet(name) {
+		*dst = c.String(name)
+	}
+}
+
+// setBool sets dst to the named flag, if it was given.
+func setBool(c *cli.Context, name string, dst *bool) {
+	if c.IsSet(name) {
+		*dst = c.Bool(name)
+	}
+}
+
+// setContent sets dst to the named flag, or to the contents of the file
+// given by the named flag with a "-file" suffix.
+func setContent(c *cli.Context, name string, dst *string) error {
+	setString(c, name, dst)
+	if path := c.String(name + "-file"); path != "" {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		*dst = string(content)
+	}
+	return nil
+}
+
+// setTime sets dst to the named RFC 3339 time flag, if it was given.
+func setTime(c *cli.Context, name string, dst *gophish.Time) error {
+	if !c.IsSet(name) {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, c.String(name))
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %v", name, err)
+	}
+	*dst = gophish.NewTime(t)
+	return nil
+}
+
+// parseHeaders parses "Key: Value" headers.
+func parseHeaders(headers []string) ([]gophish.Header, error) {
+	parsed := make([]gophish.Header, 0, len(headers))
+	for _, h := range headers {
+		parts := strings.SplitN(h, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid header %q, expected \"Key: Value\"", h)
+		}
+		parsed = append(parsed, gophish.Header{
+			Key:   strings.TrimSpace(parts[0]),
+			Value: strings.TrimSpace(parts[1]),
+		})
+	}
+	return parsed, nil
+}
+
+// readAttachments reads files into template attachments.
+func readAttachments(paths []string) ([]gophish.Attachment, error) {
+	attachments := make([]gophish.Attachment, 0, len(paths))
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		typ := mime.TypeByExtension(filepath.Ext(path))
+		if typ == "" {
+			typ = "application/octet-stream"
+		}
+		attachments = append(attachments, gophish.Attachment{
+			Name:    filepath.Base(path),
+			Type:    typ,
+			Content: base64.StdEncoding.EncodeToString(content),
+		})
+	}
+	return attachments, nil
+}
+
+// parseTargets parses "email[,first name[,last name[,position]]]" targets.
+func parseTargets(targets []string) []gophish.Target {
+	parsed := make([]gophish.Target, 0, len(targets))
+	for _, t := range targets {
+		fields := strings.SplitN(t, ",", 4)
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		for len(fields) < 4 {
+			fields = append(fields, "")
+		}
+		parsed = append(parsed, gophish.Target{
+			Email:     fields[0],
+			FirstName: fields[1],
+			LastName:  fields[2],
+			Position:  fields[3],
+		})
+	}
+	return parsed
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"github.com/urfave/cli"
+)
+
+func updateSendingProfileCommand() cli.Command {
+	return cli.Command{
+		Name:  "update",
+		Usage: "Update a sending profile from a file and/or flags",
+		Flags: append(sendingProfileFlags(), cli.IntFlag{
+			Name:  "profile-id",
+			Usage: "The ID of the sending profile to update",
+		}),
+		Action: func(c *cli.Context) error {
+			client := newClient(c)
+			id := c.Int("profile-id")
+			sp, err := client.SendingProfiles.GetSendingProfile(id)
+			if err != nil {
+				return err
+			}
+			if err := readObject(c, sp); err != nil {
+				return err
+			}
+			if err := applySendingProfileFlags(c, sp); err != nil {
+				return err
+			}
+			sp.ID = id
+			if err := sp.Validate(); err != nil {
+				return err
+			}
+
+			updated, err := client.SendingProfiles.UpdateSendingProfile(sp)
+			if err != nil {
+				return err
+			}
+			return printResult(c, updated)
+		},
+	}
+}
+
+func updateTemplateCommand() cli.Command {
+	return cli.Command{
+		Name:  "update",
+		Usage: "Update a template from a file and/or flags",
+		Flags: append(templateFlags(), cli.IntFlag{
+			Name:  "template-id",
+			Usage: "The ID of the template to update",
+		}),
+		Action: func(c *cli.Context) error {
+			client := newClient(c)
+			id := c.Int("template-id")
+			t, err := client.Templates.GetTemplate(id)
+			if err != nil {
+				return err
+			}
+			if err := readObject(c, t); err != nil {
+				return err
+			}
+			if err := applyTemplateFlags(c, t); err != nil {
+				return err
+			}
+			t.ID = id
+			if err := t.Validate(); err != nil {
+				return err
+			}
+
+			updated, err := client.Templates.UpdateTemplate(t)
+			if err != nil {
+				return err
+			}
+			return printResult(c, updated)
+		},
+	}
+}
+
+func updateLandingPageCommand() cli.Command {
+	return cli.Command{
+		Name:  "update",
+		Usage: "Update a landing page from a file and/or flags",
+		Flags: append(landingPageFlags(), cli.IntFlag{
+			Name:  "page-id",
+			Usage: "The ID of the landing page to update",
+		}),
+		Action: func(c *cli.Context) error {
+			client := newClient(c)
+			id := c.Int("page-id")
+			p, err := client.LandingPages.GetLandingPage(id)
+			if err != nil {
+				return err
+			}
+			if err := readObject(c, p); err != nil {
+				return err
+			}
+			if err := applyLandingPageFlags(c, p); err != nil {
+				return err
+			}
+			p.ID = id
+			if err := p.Validate(); err != nil {
+				return err
+			}
+
+			updated, err := client.LandingPages.UpdateLandingPage(p)
+			if err != nil {
+				return err
+			}
+			return printResult(c, updated)
+		},
+	}
+}
+
+func updateGroupCommand() cli.Command {
+	return cli.Command{
+		Name:  "update",
+		Usage: "Update a group from a file and/or flags",
+		Flags: append(groupFlags(), cli.IntFlag{
+			Name:  "group-id",
+			Usage: "The ID of the group to update",
+		}),
+		Action: func(c *cli.Context) error {
+			client := newClient(c)
+			id := c.Int("group-id")
+			g, err := client.Groups.GetGroup(id)
+			if err != nil {
+				return err
+			}
+			if err := readObject(c, g); err != nil {
+				return err
+			}
+			applyGroupFlags(c, g)
+			g.ID = id
+			if err := g.Validate(); err != nil {
+				return err
+			}
+
+			updated, err := client.Groups.UpdateGroup(g)
+			if err != nil {
+				return err
+			}
+			return printResult(c, updated)
+		},
+	}
+}
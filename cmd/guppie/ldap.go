@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/directory"
+	"github.com/urfave/cli"
+)
+
+// ldapImportCommand is `groups ldap-import`.
+func ldapImportCommand() cli.Command {
+	return cli.Command{
+		Name:  "ldap-import",
+		Usage: "Sync groups with users from an LDAP directory",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "url",
+				Usage: "The LDAP server, i.e. ldaps://dc1.example.com",
+			},
+			cli.StringFlag{
+				Name:  "bind-dn",
+				Usage: "The DN to bind as, anonymous if empty",
+			},
+			cli.StringFlag{
+				Name:   "bind-password",
+				Usage:  "The password to bind with",
+				EnvVar: "GUPPIE_LDAP_PASSWORD",
+			},
+			cli.BoolFlag{
+				Name:  "start-tls",
+				Usage: "Upgrade ldap:// connections with StartTLS",
+			},
+			cli.StringFlag{
+				Name:  "base-dn",
+				Usage: "Where to search, i.e. OU=Staff,DC=example,DC=com",
+			},
+			cli.StringFlag{
+				Name:  "filter",
+				Usage: "The LDAP filter selecting users to import",
+				Value: directory.DefaultFilter,
+			},
+			cli.StringFlag{
+				Name:  "group-by",
+				Usage: "Create a group per value of this attribute (i.e. department), or per OU with \"ou\"",
+			},
+			cli.StringFlag{
+				Name:  "group",
+				Usage: "The group to import into, or for users without a --group-by value",
+			},
+			cli.StringFlag{
+				Name:  "group-prefix",
+				Usage: "Prefix each group's name with this",
+			},
+			cli.StringFlag{
+				Name:  "email-attr",
+				Usage: "The attribute holding users' email addresses",
+				Value: directory.DefaultAttributes.Email,
+			},
+			cli.StringFlag{
+				Name:  "first-name-attr",
+				Usage: "The attribute holding users' first names",
+				Value: directory.DefaultAttributes.FirstName,
+			},
+			cli.StringFlag{
+				Name:  "last-name-attr",
+				Usage: "The attribute holding users' last names",
+				Value: directory.DefaultAttributes.LastName,
+			},
+			cli.StringFlag{
+				Name:  "position-attr",
+				Usage: "The attribute holding users' positions",
+				Value: directory.DefaultAttributes.Position,
+			},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Only show what would change",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			dir, err := directory.DialLDAP(directory.LDAPConfig{
+				URL:          c.String("url"),
+				BindDN:       c.String("bind-dn"),
+				BindPassword: c.String("bind-password"),
+				StartTLS:     c.Bool("start-tls"),
+			})
+			if err != nil {
+				return err
+			}
+			defer dir.Close()
+
+			client := newClient(c)
+			result, err := directory.Import(context.Background(), dir, client.Groups, directory.ImportOptions{
+				BaseDN: c.String("base-dn"),
+				Filter: c.String("filter"),
+				Attributes: &directory.AttributeMap{
+					Email:     c.String("email-attr"),
+					FirstName: c.String("first-name-attr"),
+					LastName:  c.String("last-name-attr"),
+					Position:  c.String("position-attr"),
+				},
+				GroupBy:     c.String("group-by"),
+				Group:       c.String("group"),
+				GroupPrefix: c.String("group-prefix"),
+				DryRun:      c.Bool("dry-run"),
+			})
+			if result != nil {
+				for _, skipped := range result.Skipped {
+					fmt.Fprintf(os.Stderr, "skipping %s: %s\n", skipped.DN, skipped.Reason)
+				}
+				for _, synced := range result.Groups {
+					if synced.Created {
+						info(c, "Creating group %q\n", synced.Group.Name)
+					} else {
+						info(c, "Group %q\n", synced.Group.Name)
+					}
+					info(c, "%s", synced.Diff)
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			if structuredOutput(c) {
+				return printResult(c, result)
+			}
+			if c.Bool("dry-run") {
+				return nil
+			}
+			groups := make([]*gophish.Group, len(result.Groups))
+			for i, synced := range result.Groups {
+				groups[i] = synced.Group
+			}
+			return printResult(c, groups)
+		},
+	}
+}
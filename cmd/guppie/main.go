@@ -1,27 +1,48 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"os"
+	"time"
 
 	"github.com/ttacon/gophish"
-	"github.com/ttacon/pretty"
 	"github.com/urfave/cli"
 )
 
 func main() {
 	app := &cli.App{
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			cli.StringFlag{
 				Name:  "host",
-				Usage: "The host to connect to",
+				Usage: "The host to connect to (default: $GOPHISH_HOST, unless a profile is used)",
 			},
 			cli.StringFlag{
 				Name:  "token",
-				Usage: "The API token to use to connect",
+				Usage: "The API token to use to connect (default: $GOPHISH_TOKEN, unless a profile is used)",
 			},
-		},
+			cli.BoolFlag{
+				Name:  "insecure",
+				Usage: "Skip verification of the gophish TLS certificate",
+			},
+			cli.StringFlag{
+				Name:  "ca-cert",
+				Usage: "A PEM file of certificate authorities to trust",
+			},
+			cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "The time limit for each request (e.g. 30s)",
+			},
+			cli.IntFlag{
+				Name:  "retries",
+				Usage: "How many times to retry failed idempotent requests",
+			},
+			cli.BoolFlag{
+				Name:  "debug",
+				Usage: "Dump every request and response to stderr",
+			},
+		}, append(configFlags(), outputFlags()...)...),
+		Before: checkOutput,
 		Commands: []cli.Command{
 			{
 				Name:        "sending-profiles",
@@ -53,11 +74,23 @@ func main() {
 				Usage:       "Manipulate campaigns",
 				Subcommands: campaignCommands(),
 			},
+			applyCommand(),
+			exportCommand(),
+			importCommand(),
+			migrateCommand(),
+			{
+				Name:        "config",
+				Usage:       "Manage profiles of gophish instances to connect to",
+				Subcommands: configCommands(),
+			},
 		},
 	}
 
+	// Errors go to stderr, so they don't end up mixed with the output of
+	// commands piped to other programs.
 	if err := app.Run(os.Args); err != nil {
-		log.Fatal(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 }
 
@@ -67,17 +100,12 @@ func sendingProfileCommands() []cli.Command {
 			Name:  "list",
 			Usage: "List all sending profiles",
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.SendingProfiles.ListSendingProfiles()
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
 		{
@@ -90,19 +118,14 @@ func sendingProfileCommands() []cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.SendingProfiles.GetSendingProfile(
 					c.Int("profile-id"),
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
 		{
@@ -115,21 +138,18 @@ func sendingProfileCommands() []cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.SendingProfiles.DeleteSendingProfile(
 					c.Int("profile-id"),
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
+		createSendingProfileCommand(),
+		updateSendingProfileCommand(),
 	}
 }
 
@@ -139,17 +159,12 @@ func templatesCommands() []cli.Command {
 			Name:  "list",
 			Usage: "List all templates",
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.Templates.ListTemplates()
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
 		{
@@ -162,19 +177,14 @@ func templatesCommands() []cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.Templates.GetTemplate(
 					c.Int("template-id"),
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
 		{
@@ -187,21 +197,18 @@ func templatesCommands() []cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.Templates.DeleteTemplate(
 					c.Int("template-id"),
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
+		createTemplateCommand(),
+		updateTemplateCommand(),
 	}
 }
 
@@ -211,17 +218,12 @@ func landingPagesCommands() []cli.Command {
 			Name:  "list",
 			Usage: "List all landing pages",
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.LandingPages.ListLandingPages()
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
 		{
@@ -234,19 +236,14 @@ func landingPagesCommands() []cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.LandingPages.GetLandingPage(
 					c.Int("page-id"),
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
 		{
@@ -259,19 +256,14 @@ func landingPagesCommands() []cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.LandingPages.DeleteLandingPage(
 					c.Int("page-id"),
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
 		{
@@ -285,22 +277,19 @@ func landingPagesCommands() []cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.LandingPages.ImportSite(gophish.ImportSiteRequest{
 					URL: c.String("url"),
 				},
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
+		createLandingPageCommand(),
+		updateLandingPageCommand(),
 	}
 }
 
@@ -309,18 +298,27 @@ func groupCommands() []cli.Command {
 		{
 			Name:  "list",
 			Usage: "List all groups",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "full",
+					Usage: "Include each group's targets, rather than summaries",
+				},
+			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
-				profiles, err := client.Groups.ListGroups()
+				client := newClient(c)
+				if c.Bool("full") {
+					groups, err := client.Groups.ListGroups()
+					if err != nil {
+						return err
+					}
+					return printResult(c, groups)
+				}
+
+				summaries, err := client.Groups.ListGroupSummaries()
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, summaries)
 			},
 		},
 		{
@@ -333,19 +331,14 @@ func groupCommands() []cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.Groups.GetGroup(
 					c.Int("group-id"),
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
 		{
@@ -358,21 +351,94 @@ func groupCommands() []cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.Groups.DeleteGroup(
 					c.Int("group-id"),
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
+			},
+		},
+		{
+			Name:    "import",
+			Aliases: []string{"imp"},
+			Usage:   "Import targets from a CSV file, optionally saving them to a group",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "file",
+					Usage: "The CSV file of targets to import",
+				},
+				cli.StringFlag{
+					Name:  "name",
+					Usage: "The name of the group to save the targets to",
+				},
+				cli.BoolFlag{
+					Name:  "merge",
+					Usage: "Add the targets to the existing group instead of replacing its targets",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				client := newClient(c)
+				profiles, err := client.Groups.ImportGroup(gophish.ImportGroupRequest{
+					File:  c.String("file"),
+					Name:  c.String("name"),
+					Merge: c.Bool("merge"),
+				})
+				if err != nil {
+					return err
+				}
+				return printResult(c, profiles)
+			},
+		},
+		{
+			Name:  "sync",
+			Usage: "Make a group's targets match a CSV or XLSX roster",
+			Flags: append(rosterFlags(),
+				cli.StringFlag{
+					Name:  "name",
+					Usage: "The name of the group to sync, it's created if missing",
+				},
+				cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Only show what would change",
+				},
+			),
+			Action: func(c *cli.Context) error {
+				targets, err := loadRoster(c)
+				if err != nil {
+					return err
+				}
+
+				client := newClient(c)
+				result, err := client.Groups.SyncGroup(
+					c.String("name"),
+					targets,
+					gophish.SyncOptions{DryRun: c.Bool("dry-run")},
+				)
+				if err != nil {
+					return err
+				}
+
+				if result.Created {
+					info(c, "Creating group %q\n", c.String("name"))
+				}
+				info(c, "%s", result.Diff)
+				if structuredOutput(c) {
+					return printResult(c, result)
+				}
+				if c.Bool("dry-run") {
+					return nil
+				}
+				return printResult(c, result.Group)
 			},
 		},
+		ldapImportCommand(),
+		splitCommand(),
+		sampleCommand(),
+		createGroupCommand(),
+		updateGroupCommand(),
 	}
 }
 
@@ -382,17 +448,12 @@ func campaignCommands() []cli.Command {
 			Name:  "list",
 			Usage: "List all campaigns",
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.Campaigns.ListCampaigns()
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
 		{
@@ -405,19 +466,14 @@ func campaignCommands() []cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.Campaigns.GetCampaign(
 					c.Int("campaign-id"),
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
 		{
@@ -430,19 +486,14 @@ func campaignCommands() []cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.Campaigns.DeleteCampaign(
 					c.Int("campaign-id"),
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
 		{
@@ -453,21 +504,45 @@ func campaignCommands() []cli.Command {
 					Name:  "campaign-id",
 					Usage: "The ID of the campaign to complete",
 				},
+				cli.BoolFlag{
+					Name:  "wait",
+					Usage: "Wait until the campaign's status is Completed",
+				},
+				cli.DurationFlag{
+					Name:  "wait-timeout",
+					Usage: "How long to wait for the campaign to complete",
+					Value: 5 * time.Minute,
+				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
-				profiles, err := client.Campaigns.CompleteCampaign(
+				client := newClient(c)
+				completion, err := client.Campaigns.CompleteCampaign(
 					c.Int("campaign-id"),
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				info(c, "%s\n", completion.Message)
+
+				campaign := completion.Campaign
+				if c.Bool("wait") {
+					ctx, cancel := context.WithTimeout(
+						context.Background(),
+						c.Duration("wait-timeout"),
+					)
+					defer cancel()
+
+					campaign, err = client.Campaigns.WaitForCampaignStatus(
+						ctx,
+						c.Int("campaign-id"),
+						gophish.CampaignCompleted,
+						2*time.Second,
+					)
+					if err != nil {
+						return err
+					}
+				}
+				return printResult(c, campaign)
 			},
 		},
 		{
@@ -480,20 +555,16 @@ func campaignCommands() []cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				client := gophish.NewClient(
-					c.GlobalString("host"),
-					c.GlobalString("token"),
-				)
+				client := newClient(c)
 				profiles, err := client.Campaigns.GetCampaignSummary(
 					c.Int("campaign-id"),
 				)
 				if err != nil {
-					fmt.Println(err)
 					return err
 				}
-				pretty.Println(profiles)
-				return nil
+				return printResult(c, profiles)
 			},
 		},
+		createCampaignCommand(),
 	}
 }
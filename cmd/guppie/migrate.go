@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/backup"
+	"github.com/ttacon/gophish/manifest"
+	"github.com/ttacon/gophish/migrate"
+	"github.com/urfave/cli"
+	"golang.org/x/term"
+)
+
+// migrateKinds maps the names of guppie's commands to the kinds of
+// resources they manage.
+var migrateKinds = map[string]string{
+	"sending-profiles": manifest.KindSendingProfile,
+	"templates":        manifest.KindTemplate,
+	"landing-pages":    manifest.KindLandingPage,
+	"groups":           manifest.KindGroup,
+	"campaigns":        manifest.KindCampaign,
+}
+
+// migrateCommand is `migrate`.
+func migrateCommand() cli.Command {
+	return cli.Command{
+		Name:  "migrate",
+		Usage: "Copy resources from one profile's gophish instance to another's",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "from",
+				Usage: "The profile to copy from",
+			},
+			cli.StringFlag{
+				Name:  "to",
+				Usage: "The profile to copy to",
+			},
+			cli.StringSliceFlag{
+				Name:  "kind",
+				Usage: "Copy sending-profiles, templates, landing-pages, groups or campaigns (repeatable, all but campaigns by default)",
+			},
+			cli.StringSliceFlag{
+				Name:  "name",
+				Usage: "Only copy resources with this name (repeatable)",
+			},
+			cli.StringFlag{
+				Name:  "on-conflict",
+				Usage: "What to do with resources whose names are taken: skip, rename or overwrite",
+				Value: string(backup.Skip),
+			},
+			cli.StringFlag{
+				Name:  "passwords",
+				Usage: "Whether to redact sending profile passwords or prompt for them",
+				Value: "redact",
+			},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Only show what would be copied",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if c.String("from") == "" || c.String("to") == "" {
+				return errors.New("both --from and --to profiles are required")
+			}
+			policy, err := backup.ParseCollisionPolicy(c.String("on-conflict"))
+			if err != nil {
+				return err
+			}
+			opts := migrate.Options{
+				Names:      c.StringSlice("name"),
+				OnConflict: policy,
+				DryRun:     c.Bool("dry-run"),
+			}
+			for _, name := range c.StringSlice("kind") {
+				kind, ok := migrateKinds[name]
+				if !ok {
+					return fmt.Errorf("unknown kind %q", name)
+				}
+				opts.Kinds = append(opts.Kinds, kind)
+			}
+			switch c.String("passwords") {
+			case "redact":
+			case "prompt":
+				opts.Password = promptPassword
+			default:
+				return fmt.Errorf("unknown --passwords %q, expected redact or prompt", c.String("passwords"))
+			}
+
+			report, err := migrate.Migrate(
+				context.Background(),
+				profileClient(c, c.String("from")),
+				profileClient(c, c.String("to")),
+				opts,
+			)
+			if report != nil && (err == nil || len(report.Entries) > 0) {
+				if structuredOutput(c) {
+					if err := printResult(c, report.Entries); err != nil {
+						return err
+					}
+				} else {
+					info(c, "%s", report)
+				}
+			}
+			return err
+		},
+	}
+}
+
+// stdin is shared by prompts, so that input buffered by one isn't lost to
+// the next.
+var stdin = bufio.NewReader(os.Stdin)
+
+// promptPassword asks for a sending profile's password on the terminal,
+// without echoing it. When stdin isn't a terminal, i.e. passwords are piped
+// in, a line is read from it instead.
+func promptPassword(sp gophish.SendingProfile) (string, error) {
+	fmt.Fprintf(os.Stderr, "Password for sending profile %q (%s@%s): ", sp.Name, sp.Username, sp.Host)
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		password, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(password), err
+	}
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,486 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/directory"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+// The formats of the global --output flag. Templates are given as
+// "template=TEXT".
+const (
+	outputPretty   = "pretty"
+	outputJSON     = "json"
+	outputYAML     = "yaml"
+	outputTable    = "table"
+	outputCSV      = "csv"
+	outputTemplate = "template"
+)
+
+// defaultColumns are the fields shown in tables and CSV for each type of
+// result, unless overridden with --columns. Nested fields are given as
+// paths, i.e. "Stats.Clicked".
+var defaultColumns = map[reflect.Type][]string{
+	reflect.TypeOf(gophish.SendingProfile{}):           {"ID", "Name", "Host", "FromAddress", "ModifiedDate"},
+	reflect.TypeOf(gophish.Template{}):                 {"ID", "Name", "Subject", "Attachments", "ModifiedDate"},
+	reflect.TypeOf(gophish.LandingPage{}):              {"ID", "Name", "CaptureCredentials", "RedirectURL", "ModifiedDate"},
+	reflect.TypeOf(gophish.Group{}):                    {"ID", "Name", "Targets", "ModifiedDate"},
+	reflect.TypeOf(gophish.GroupSummary{}):             {"ID", "Name", "NumTargets", "ModifiedDate"},
+	reflect.TypeOf(gophish.Target{}):                   {"Email", "FirstName", "LastName", "Position"},
+	reflect.TypeOf(gophish.Campaign{}):                 {"ID", "Name", "Status", "LaunchDate"},
+	reflect.TypeOf(gophish.CampaignResult{}):           {"Email", "FirstName", "LastName", "Status"},
+	reflect.TypeOf(gophish.CompleteCampaignResponse{}): {"Success", "Message"},
+	reflect.TypeOf(gophish.SyncResult{}):               {"Group", "Created", "Diff.Added", "Diff.Removed", "Diff.Changed", "Diff.Unchanged"},
+	reflect.TypeOf(directory.ImportResult{}):           {"Groups", "Skipped"},
+}
+
+var timeType = reflect.TypeOf(gophish.Time{})
+
+// outputFlags are the global flags configuring printResult.
+func outputFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "Print results as pretty, json, yaml, table, csv or template=TEXT (a Go template run for each result)",
+			Value: outputPretty,
+		},
+		cli.StringFlag{
+			Name:  "columns",
+			Usage: "The fields shown by --output table and csv, i.e. --columns ID,Name,Stats.Clicked",
+		},
+	}
+}
+
+// checkOutput validates the output flags before running any command, so that
+// a typo doesn't only show up after, say, a campaign has been created.
+func checkOutput(c *cli.Context) error {
+	format, text := outputFormat(c)
+	switch format {
+	case outputPretty, outputJSON, outputYAML, outputTable, outputCSV:
+		return nil
+	case outputTemplate:
+		_, err := template.New("output").Parse(text)
+		return err
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
+
+func outputFormat(c *cli.Context) (format, text string) {
+	format = c.GlobalString("output")
+	if i := strings.Index(format, "="); i >= 0 {
+		return format[:i], format[i+1:]
+	}
+	return format, ""
+}
+
+// structuredOutput reports whether results are printed for machines, in
+// which case informational messages go to stderr so they don't get mixed up
+// with results.
+func structuredOutput(c *cli.Context) bool {
+	format, _ := outputFormat(c)
+	return format != outputPretty
+}
+
+// info prints an informational message, to stdout by default and stderr
+// when printing structured output.
+func info(c *cli.Context, format string, args ...interface{}) {
+	w := c.App.Writer
+	if structuredOutput(c) {
+		w = c.App.ErrWriter
+		if w == nil {
+			w = os.Stderr
+		}
+	}
+	fmt.Fprintf(w, format, args...)
+}
+
+// printResult prints the result of a command in the format given by the
+// global --output flag.
+func printResult(c *cli.Context, v interface{}) error {
+	w := c.App.Writer
+	format, text := outputFormat(c)
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(v)
+	case outputYAML:
+		return printYAML(w, v)
+	case outputTable, outputCSV:
+		var columns []string
+		if c.GlobalString("columns") != "" {
+			for _, column := range strings.Split(c.GlobalString("columns"), ",") {
+				columns = append(columns, strings.TrimSpace(column))
+			}
+		}
+		header, rows, err := tabulate(v, columns)
+		if err != nil {
+			return err
+		}
+		if format == outputCSV {
+			return printCSV(w, header, rows)
+		}
+		return printTable(w, header, rows)
+	case outputTemplate:
+		tmpl, err := template.New("output").Parse(text)
+		if err != nil {
+			return err
+		}
+		for _, item := range items(v) {
+			if err := tmpl.Execute(w, item.Interface()); err != nil {
+				return err
+			}
+			fmt.Fprintln(w)
+		}
+		return nil
+	}
+	return printPretty(w, v)
+}
+
+// printPretty prints v as indented Go syntax, like github.com/ttacon/pretty
+// did, but with timestamps as RFC 3339 strings rather than the internals of
+// a time.Time. Unexported fields are left out.
+func printPretty(w io.Writer, v interface{}) error {
+	var b strings.Builder
+	writePretty(&b, reflect.ValueOf(v), true, "")
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writePretty writes v at the given indentation, with its type if showType
+// is set. Like Go's composite literals, types are left out where they're
+// implied, i.e. for the elements of slices.
+func writePretty(b *strings.Builder, v reflect.Value, showType bool, indent string) {
+	if !v.IsValid() {
+		b.WriteString("nil")
+		return
+	}
+	if v.Type() == timeType {
+		var s string
+		if t := v.Interface().(gophish.Time); t.IsSet() {
+			s = t.Format(time.RFC3339)
+		}
+		b.WriteString(strconv.Quote(s))
+		return
+	}
+
+	inner := indent + "    "
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			fmt.Fprintf(b, "(%s)(nil)", v.Type())
+			return
+		}
+		b.WriteString("&")
+		writePretty(b, v.Elem(), true, indent)
+	case reflect.Interface:
+		if v.IsNil() {
+			b.WriteString("nil")
+			return
+		}
+		writePretty(b, v.Elem(), true, indent)
+	case reflect.Struct:
+		if showType {
+			b.WriteString(v.Type().String())
+		}
+		typ := v.Type()
+		var fields []int
+		width := 0
+		for i := 0; i < typ.NumField(); i++ {
+			if f := typ.Field(i); f.PkgPath == "" {
+				fields = append(fields, i)
+				if len(f.Name) > width {
+					width = len(f.Name)
+				}
+			}
+		}
+		if len(fields) == 0 || v.IsZero() {
+			b.WriteString("{}")
+			return
+		}
+		b.WriteString("{\n")
+		for _, i := range fields {
+			field := v.Field(i)
+			fmt.Fprintf(b, "%s%-*s ", inner, width+1, typ.Field(i).Name+":")
+			kind := field.Kind()
+			writePretty(b, field, kind == reflect.Struct || kind == reflect.Interface, inner)
+			b.WriteString(",\n")
+		}
+		b.WriteString(indent + "}")
+	case reflect.Slice, reflect.Array:
+		if showType {
+			b.WriteString(v.Type().String())
+		}
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			if showType {
+				b.WriteString("(nil)")
+			} else {
+				b.WriteString("nil")
+			}
+			return
+		}
+		if v.Len() == 0 {
+			b.WriteString("{}")
+			return
+		}
+		b.WriteString("{\n")
+		for i := 0; i < v.Len(); i++ {
+			b.WriteString(inner)
+			writePretty(b, v.Index(i), v.Type().Elem().Kind() == reflect.Interface, inner)
+			b.WriteString(",\n")
+		}
+		b.WriteString(indent + "}")
+	case reflect.Map:
+		if showType {
+			b.WriteString(v.Type().String())
+		}
+		if v.Len() == 0 {
+			b.WriteString("{}")
+			return
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		b.WriteString("{\n")
+		for _, key := range keys {
+			b.WriteString(inner)
+			writePretty(b, key, false, inner)
+			b.WriteString(": ")
+			writePretty(b, v.MapIndex(key), v.Type().Elem().Kind() == reflect.Interface, inner)
+			b.WriteString(",\n")
+		}
+		b.WriteString(indent + "}")
+	case reflect.String:
+		b.WriteString(strconv.Quote(v.String()))
+	case reflect.Bool:
+		b.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		b.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		b.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	default:
+		fmt.Fprintf(b, "%#v", v.Interface())
+	}
+}
+
+// printYAML prints v as YAML, with the same field names and order as JSON.
+func printYAML(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	ordered, err := orderedValue(dec)
+	if err != nil {
+		return err
+	}
+	out, err := yaml.Marshal(ordered)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// orderedValue decodes the next JSON value, keeping the order of object
+// keys.
+func orderedValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := yaml.MapSlice{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := orderedValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, yaml.MapItem{Key: key, Value: value})
+		}
+		_, err := dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []interface{}{}
+		for dec.More() {
+			value, err := orderedValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err := dec.Token()
+		return arr, err
+	}
+	if n, ok := tok.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+		return n.Float64()
+	}
+	return tok, nil
+}
+
+// items returns the elements of v if it's a slice, or v itself.
+func items(v interface{}) []reflect.Value {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice {
+		return []reflect.Value{rv}
+	}
+	values := make([]reflect.Value, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i)
+	}
+	return values
+}
+
+// tabulate turns v into rows of the given columns, or of the default
+// columns of its type.
+func tabulate(v interface{}, columns []string) (header []string, rows [][]string, err error) {
+	values := items(v)
+
+	typ := reflect.TypeOf(v)
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if len(columns) == 0 {
+		columns = columnsOf(typ)
+	}
+	if len(columns) == 0 {
+		// Not a struct, i.e. the result of a delete.
+		for _, value := range values {
+			rows = append(rows, []string{cell(value)})
+		}
+		return []string{"Value"}, rows, nil
+	}
+
+	for _, value := range values {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			field, err := fieldByPath(value, column)
+			if err != nil {
+				return nil, nil, err
+			}
+			row[i] = cell(field)
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows, nil
+}
+
+// columnsOf returns the default columns of a type, falling back to all its
+// exported fields.
+func columnsOf(typ reflect.Type) []string {
+	if columns, ok := defaultColumns[typ]; ok {
+		return columns
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	var columns []string
+	for i := 0; i < typ.NumField(); i++ {
+		if f := typ.Field(i); f.PkgPath == "" {
+			columns = append(columns, f.Name)
+		}
+	}
+	return columns
+}
+
+func fieldByPath(v reflect.Value, path string) (reflect.Value, error) {
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, nil
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("unknown column %q", path)
+		}
+		field := v.FieldByName(name)
+		if !field.IsValid() {
+			return reflect.Value{}, fmt.Errorf("unknown column %q for %s", path, v.Type().Name())
+		}
+		v = field
+	}
+	return v, nil
+}
+
+// cell formats a field for a table or CSV: times as RFC 3339, lists as
+// their length and nested resources by name.
+func cell(v reflect.Value) string {
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+	if v.Type() == timeType {
+		t := v.Interface().(gophish.Time)
+		if !t.IsSet() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		return fmt.Sprint(v.Len())
+	case reflect.Struct:
+		if name := v.FieldByName("Name"); name.IsValid() && name.Kind() == reflect.String {
+			return name.String()
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func printTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func printCSV(w io.Writer, header []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
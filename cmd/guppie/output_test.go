@@ -0,0 +1,383 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ttacon/gophish"
+	"github.com/urfave/cli"
+)
+
+var testTime = gophish.NewTime(time.Date(2020, 5, 8, 15, 7, 1, 0, time.UTC))
+
+func TestPrintPretty(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{
+			"dates",
+			[]gophish.GroupSummary{
+				{ID: 1, Name: "Staff", NumTargets: 2, ModifiedDate: testTime},
+				{ID: 2, Name: "Unset"},
+			},
+			`[]gophish.GroupSummary{
+    {
+        ID:           1,
+        Name:         "Staff",
+        NumTargets:   2,
+        ModifiedDate: "2020-05-08T15:07:01Z",
+    },
+    {
+        ID:           2,
+        Name:         "Unset",
+        NumTargets:   0,
+        ModifiedDate: "",
+    },
+}
+`,
+		},
+		{
+			"nested",
+			&gophish.Group{
+				ID:           1,
+				Name:         "Staff",
+				Targets:      []gophish.Target{{Email: "jdoe@example.com", Position: "CFO"}},
+				ModifiedDate: testTime,
+			},
+			`&gophish.Group{
+    ID:           1,
+    Name:         "Staff",
+    Targets:      {
+        {
+            Email:     "jdoe@example.com",
+            FirstName: "",
+            LastName:  "",
+            Position:  "CFO",
+        },
+    },
+    ModifiedDate: "2020-05-08T15:07:01Z",
+}
+`,
+		},
+		{
+			"scalars",
+			gophish.CampaignResult{
+				Email:     "jdoe@example.com",
+				Status:    gophish.ResultClickedLink,
+				Latitude:  51.5,
+				SendDate:  testTime,
+				Reported:  true,
+				Longitude: -0.125,
+			},
+			`gophish.CampaignResult{
+    ID:        "",
+    Email:     "jdoe@example.com",
+    FirstName: "",
+    LastName:  "",
+    Position:  "",
+    Status:    "Clicked Link",
+    IP:        "",
+    Latitude:  51.5,
+    Longitude: -0.125,
+    SendDate:  "2020-05-08T15:07:01Z",
+    Reported:  true,
+}
+`,
+		},
+		{"nil slice", []gophish.Template(nil), "[]gophish.Template(nil)\n"},
+		{"empty struct", gophish.Campaign{}, "gophish.Campaign{}\n"},
+		{"bool", true, "true\n"},
+		{"map", map[string]int{"b": 2, "a": 1}, "map[string]int{\n    \"a\": 1,\n    \"b\": 2,\n}\n"},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := printPretty(&b, tt.v); err != nil {
+			t.Fatal(err)
+		}
+		got := b.String()
+		if strings.Contains(got, "wall") || strings.Contains(got, "time.Time") {
+			t.Errorf("%s: got the internals of a time:\n%s", tt.name, got)
+		}
+		if tt.want != "" && got != tt.want {
+			t.Errorf("%s: got:\n%s\nwant:\n%s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// outputContext returns the context of a command run with the given global
+// --output and --columns flags, printing to out.
+func outputContext(out *bytes.Buffer, output, columns string) *cli.Context {
+	app := cli.NewApp()
+	app.Writer = out
+	global := flag.NewFlagSet("guppie", flag.ContinueOnError)
+	global.String("output", output, "")
+	global.String("columns", columns, "")
+	return cli.NewContext(app, flag.NewFlagSet("command", flag.ContinueOnError), cli.NewContext(app, global, nil))
+}
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		output       string
+		format, text string
+		valid        bool
+	}{
+		{"pretty", outputPretty, "", true},
+		{"json", outputJSON, "", true},
+		{"yaml", outputYAML, "", true},
+		{"table", outputTable, "", true},
+		{"csv", outputCSV, "", true},
+		{"template={{.Name}}", outputTemplate, "{{.Name}}", true},
+		// Only the first = separates the format from the template.
+		{"template={{if eq .Name \"a=b\"}}yes{{end}}", outputTemplate, "{{if eq .Name \"a=b\"}}yes{{end}}", true},
+		{"template=", outputTemplate, "", true},
+		{"template={{.Name", outputTemplate, "{{.Name", false},
+		{"JSON", "JSON", "", false},
+		{"xml", "xml", "", false},
+		{"json=", outputJSON, "", true},
+	}
+	for _, tt := range tests {
+		c := outputContext(&bytes.Buffer{}, tt.output, "")
+		format, text := outputFormat(c)
+		if format != tt.format || text != tt.text {
+			t.Errorf("%q: got format %q and text %q, want %q and %q", tt.output, format, text, tt.format, tt.text)
+		}
+		if err := checkOutput(c); (err == nil) != tt.valid {
+			t.Errorf("%q: got error %v, want valid %v", tt.output, err, tt.valid)
+		}
+	}
+}
+
+func TestPrintTemplate(t *testing.T) {
+	var out bytes.Buffer
+	c := outputContext(&out, "template={{.Name}} ({{len .Targets}})", "")
+	groups := []gophish.Group{
+		{Name: "Staff", Targets: []gophish.Target{{Email: "jdoe@example.com"}}},
+		{Name: "Interns"},
+	}
+	if err := printResult(c, groups); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Staff (1)\nInterns (0)\n"; out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
+
+func TestDefaultColumns(t *testing.T) {
+	for typ, columns := range defaultColumns {
+		v := reflect.New(typ).Elem()
+		for _, column := range columns {
+			if _, err := fieldByPath(v, column); err != nil {
+				t.Errorf("%s: %v", typ, err)
+			}
+		}
+	}
+}
+
+func TestFieldByPath(t *testing.T) {
+	result := &gophish.SyncResult{
+		Group: &gophish.Group{Name: "Staff"},
+		Diff:  &gophish.GroupDiff{Unchanged: 3},
+	}
+	v := reflect.ValueOf(result)
+
+	tests := []struct {
+		path string
+		want interface{}
+	}{
+		{"Created", false},
+		{"Group.Name", "Staff"},
+		{"Diff.Unchanged", 3},
+	}
+	for _, tt := range tests {
+		field, err := fieldByPath(v, tt.path)
+		if err != nil {
+			t.Errorf("%s: %v", tt.path, err)
+			continue
+		}
+		if got := field.Interface(); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	// Fields under a nil pointer are empty.
+	result.Diff = nil
+	if field, err := fieldByPath(v, "Diff.Unchanged"); err != nil || field.IsValid() {
+		t.Errorf("got %v, %v, want an invalid value", field, err)
+	}
+
+	for _, path := range []string{"Nope", "Group.Nope", "Group.Name.Length", "group.name"} {
+		if _, err := fieldByPath(v, path); err == nil {
+			t.Errorf("%s: got no error", path)
+		}
+	}
+}
+
+func TestCell(t *testing.T) {
+	var nilGroup *gophish.Group
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{"time", testTime, "2020-05-08T15:07:01Z"},
+		{"unset time", gophish.Time{}, ""},
+		{"string", "Staff", "Staff"},
+		{"int", 42, "42"},
+		{"bool", true, "true"},
+		{"status", gophish.ResultClickedLink, "Clicked Link"},
+		{"slice", []gophish.Target{{}, {}}, "2"},
+		{"nil slice", []gophish.Target(nil), "0"},
+		{"named struct", gophish.Group{ID: 1, Name: "Staff"}, "Staff"},
+		{"pointer", &gophish.Template{Name: "Invoice"}, "Invoice"},
+		{"nil pointer", nilGroup, ""},
+		{"nil", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := cell(reflect.ValueOf(tt.v)); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTabulate(t *testing.T) {
+	groups := []gophish.GroupSummary{
+		{ID: 1, Name: "Staff", NumTargets: 2, ModifiedDate: testTime},
+		{ID: 2, Name: "Interns"},
+	}
+	tests := []struct {
+		name    string
+		v       interface{}
+		columns []string
+		header  []string
+		rows    [][]string
+	}{
+		{
+			"default columns",
+			groups,
+			nil,
+			[]string{"ID", "Name", "NumTargets", "ModifiedDate"},
+			[][]string{
+				{"1", "Staff", "2", "2020-05-08T15:07:01Z"},
+				{"2", "Interns", "0", ""},
+			},
+		},
+		{
+			"columns",
+			&groups,
+			[]string{"Name", "ID"},
+			[]string{"Name", "ID"},
+			[][]string{{"Staff", "1"}, {"Interns", "2"}},
+		},
+		{
+			"single result",
+			&gophish.SyncResult{
+				Group:   &gophish.Group{Name: "Staff"},
+				Created: true,
+				Diff:    &gophish.GroupDiff{Added: []gophish.Target{{}, {}}, Unchanged: 1},
+			},
+			nil,
+			[]string{"Group", "Created", "Diff.Added", "Diff.Removed", "Diff.Changed", "Diff.Unchanged"},
+			[][]string{{"Staff", "true", "2", "0", "0", "1"}},
+		},
+		{
+			"all fields",
+			[]gophish.Header{{Key: "X-Mailer", Value: "Outlook"}},
+			nil,
+			[]string{"Key", "Value"},
+			[][]string{{"X-Mailer", "Outlook"}},
+		},
+		{
+			"not a struct",
+			"Group deleted successfully!",
+			nil,
+			[]string{"Value"},
+			[][]string{{"Group deleted successfully!"}},
+		},
+	}
+	for _, tt := range tests {
+		header, rows, err := tabulate(tt.v, tt.columns)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(header, tt.header) || !reflect.DeepEqual(rows, tt.rows) {
+			t.Errorf("%s: got %q %q, want %q %q", tt.name, header, rows, tt.header, tt.rows)
+		}
+	}
+
+	if _, _, err := tabulate(groups, []string{"Nope"}); err == nil {
+		t.Error("got no error for an unknown column")
+	}
+}
+
+func TestPrintTable(t *testing.T) {
+	var out bytes.Buffer
+	c := outputContext(&out, "table", "ID, Name")
+	if err := printResult(c, []gophish.Group{{ID: 1, Name: "Staff"}, {ID: 12, Name: "Interns"}}); err != nil {
+		t.Fatal(err)
+	}
+	want := "ID  NAME\n" +
+		"1   Staff\n" +
+		"12  Interns\n"
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestPrintCSV(t *testing.T) {
+	var out bytes.Buffer
+	c := outputContext(&out, "csv", "ID,Name,Subject")
+	templates := []gophish.Template{
+		{ID: 1, Name: `Invoice, "urgent"`, Subject: "Overdue\nPlease pay"},
+		{ID: 2, Name: "Plain", Subject: " spaced "},
+	}
+	if err := printResult(c, templates); err != nil {
+		t.Fatal(err)
+	}
+	want := "ID,Name,Subject\n" +
+		"1,\"Invoice, \"\"urgent\"\"\",\"Overdue\nPlease pay\"\n" +
+		"2,Plain,\" spaced \"\n"
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestPrintYAML(t *testing.T) {
+	var out bytes.Buffer
+	group := &gophish.Group{
+		ID:           1,
+		Name:         "Staff",
+		Targets:      []gophish.Target{{Email: "jdoe@example.com", Position: "CFO"}},
+		ModifiedDate: testTime,
+	}
+	if err := printYAML(&out, group); err != nil {
+		t.Fatal(err)
+	}
+	// Fields are in the order of the struct, as in JSON, not sorted.
+	want := `id: 1
+name: Staff
+targets:
+- email: jdoe@example.com
+  first_name: ""
+  last_name: ""
+  position: CFO
+modified_date: "2020-05-08T15:07:01Z"
+`
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := printYAML(&out, gophish.CampaignResult{Latitude: 51.5, Longitude: -0.125}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "latitude: 51.5\nlongitude: -0.125\n") {
+		t.Errorf("got:\n%s\nwant the coordinates as numbers", out.String())
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/roster"
+	"github.com/urfave/cli"
+)
+
+// rosterFlags are the flags of commands that read a roster with loadRoster.
+func rosterFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "file",
+			Usage: "The CSV or XLSX roster of targets",
+		},
+		cli.StringSliceFlag{
+			Name:  "map",
+			Usage: "Map a column to a target field, i.e. --map Mail=email (repeatable)",
+		},
+		cli.StringFlag{
+			Name:  "sheet",
+			Usage: "The worksheet to read from XLSX rosters",
+		},
+		cli.BoolFlag{
+			Name:  "title-case",
+			Usage: "Normalize first and last names to title case",
+		},
+		cli.BoolFlag{
+			Name:  "skip-invalid",
+			Usage: "Skip invalid rows instead of failing",
+		},
+	}
+}
+
+// loadRoster parses and validates the roster given by rosterFlags, printing
+// any invalid rows to stderr.
+func loadRoster(c *cli.Context) ([]gophish.Target, error) {
+	if c.String("file") == "" {
+		return nil, errors.New("--file is required")
+	}
+	columns, err := roster.ParseColumnMapping(c.StringSlice("map"))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := roster.ParseFile(c.String("file"), roster.Options{
+		Columns:        columns,
+		Sheet:          c.String("sheet"),
+		TitleCaseNames: c.Bool("title-case"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rowErr := range result.Errors {
+		fmt.Fprintln(os.Stderr, rowErr)
+	}
+	if len(result.Errors) > 0 && !c.Bool("skip-invalid") {
+		return nil, fmt.Errorf(
+			"%d invalid rows in %s, fix them or use --skip-invalid",
+			len(result.Errors),
+			c.String("file"),
+		)
+	}
+	return result.Targets, nil
+}
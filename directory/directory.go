@@ -0,0 +1,201 @@
+// Package directory imports recipients from a directory service (i.e.
+// Active Directory over LDAP) into gophish groups.
+package directory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Entry is a directory entry.
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Get returns the first value of the given attribute, matched case
+// insensitively like LDAP attribute names are.
+func (e Entry) Get(attr string) string {
+	for name, values := range e.Attributes {
+		if strings.EqualFold(name, attr) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// OU returns the organizational unit the entry is directly in, i.e.
+// "Finance" for "CN=Jane Doe,OU=Finance,OU=Staff,DC=example,DC=com".
+func (e Entry) OU() string {
+	dn, err := ldap.ParseDN(e.DN)
+	if err != nil {
+		return ""
+	}
+	for _, rdn := range dn.RDNs {
+		for _, attr := range rdn.Attributes {
+			if strings.EqualFold(attr.Type, "ou") {
+				return attr.Value
+			}
+		}
+	}
+	return ""
+}
+
+// Searcher searches a directory for entries under baseDN matching an LDAP
+// filter, returning only the requested attributes. It's implemented by LDAP,
+// and by Static for testing without a directory server.
+type Searcher interface {
+	Search(ctx context.Context, baseDN, filter string, attrs []string) ([]Entry, error)
+}
+
+// Static is an in-memory directory. It supports the common subset of LDAP
+// filters: and, or, not, equality, presence and substring matches, all case
+// insensitive.
+type Static []Entry
+
+// Search implements Searcher.
+func (s Static) Search(ctx context.Context, baseDN, filter string, attrs []string) ([]Entry, error) {
+	compiled, err := ldap.CompileFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, e := range s {
+		if !underBase(e.DN, baseDN) {
+			continue
+		}
+		ok, err := matchFilter(compiled, e)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			entries = append(entries, e.only(attrs))
+		}
+	}
+	return entries, nil
+}
+
+// only returns a copy of the entry with only the given attributes.
+func (e Entry) only(attrs []string) Entry {
+	out := Entry{DN: e.DN, Attributes: make(map[string][]string, len(attrs))}
+	for name, values := range e.Attributes {
+		for _, attr := range attrs {
+			if strings.EqualFold(name, attr) {
+				out.Attributes[name] = append([]string(nil), values...)
+			}
+		}
+	}
+	return out
+}
+
+func underBase(dn, baseDN string) bool {
+	if baseDN == "" {
+		return true
+	}
+	parsed, err := ldap.ParseDN(strings.ToLower(dn))
+	if err != nil {
+		return false
+	}
+	base, err := ldap.ParseDN(strings.ToLower(baseDN))
+	if err != nil {
+		return false
+	}
+	return base.Equal(parsed) || base.AncestorOf(parsed)
+}
+
+func matchFilter(f *ber.Packet, e Entry) (bool, error) {
+	switch f.Tag {
+	case ldap.FilterAnd:
+		for _, child := range f.Children {
+			ok, err := matchFilter(child, e)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case ldap.FilterOr:
+		for _, child := range f.Children {
+			ok, err := matchFilter(child, e)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case ldap.FilterNot:
+		ok, err := matchFilter(f.Children[0], e)
+		return !ok, err
+	case ldap.FilterPresent:
+		return len(e.values(ber.DecodeString(f.Data.Bytes()))) > 0, nil
+	case ldap.FilterEqualityMatch:
+		attr := ber.DecodeString(f.Children[0].Data.Bytes())
+		want := ber.DecodeString(f.Children[1].Data.Bytes())
+		for _, v := range e.values(attr) {
+			if strings.EqualFold(v, want) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case ldap.FilterSubstrings:
+		attr := ber.DecodeString(f.Children[0].Data.Bytes())
+		for _, v := range e.values(attr) {
+			if matchSubstrings(strings.ToLower(v), f.Children[1].Children) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf(
+		"directory: unsupported filter type %q",
+		ldap.FilterMap[uint64(f.Tag)],
+	)
+}
+
+func matchSubstrings(v string, parts []*ber.Packet) bool {
+	for _, part := range parts {
+		s := strings.ToLower(ber.DecodeString(part.Data.Bytes()))
+		switch part.Tag {
+		case ldap.FilterSubstringsInitial:
+			if !strings.HasPrefix(v, s) {
+				return false
+			}
+			v = v[len(s):]
+		case ldap.FilterSubstringsAny:
+			i := strings.Index(v, s)
+			if i < 0 {
+				return false
+			}
+			v = v[i+len(s):]
+		case ldap.FilterSubstringsFinal:
+			if !strings.HasSuffix(v, s) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (e Entry) values(attr string) []string {
+	var values []string
+	for name, vs := range e.Attributes {
+		if strings.EqualFold(name, attr) {
+			values = append(values, vs...)
+		}
+	}
+	return values
+}
+
+// sortedKeys returns the keys of a map of group names in order.
+func sortedKeys(m map[string][]Entry) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
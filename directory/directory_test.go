@@ -0,0 +1,321 @@
+package directory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/gophishtest"
+)
+
+func person(dn, mail, givenName, sn, department string) Entry {
+	attrs := map[string][]string{
+		"objectClass": {"top", "person", "inetOrgPerson"},
+		"givenName":   {givenName},
+		"sn":          {sn},
+	}
+	if mail != "" {
+		attrs["mail"] = []string{mail}
+	}
+	if department != "" {
+		attrs["department"] = []string{department}
+	}
+	return Entry{DN: dn, Attributes: attrs}
+}
+
+var staff = Static{
+	person("CN=Jane Doe,OU=Finance,OU=Staff,DC=example,DC=com", "JDoe@example.com", "Jane", "Doe", "Accounting"),
+	person("CN=John Smith,OU=Finance,OU=Staff,DC=example,DC=com", "jsmith@example.com", "John", "Smith", ""),
+	person("CN=Bruce Wayne,OU=IT,OU=Staff,DC=example,DC=com", "bwayne@example.com", "Bruce", "Wayne", "Engineering"),
+	person("CN=No Mail,OU=IT,OU=Staff,DC=example,DC=com", "", "No", "Mail", "Engineering"),
+	person("CN=Admin,OU=Contractors,DC=example,DC=com", "admin@example.com", "Site", "Admin", "Engineering"),
+	{
+		DN:         "CN=Printers,OU=Staff,DC=example,DC=com",
+		Attributes: map[string][]string{"objectClass": {"top", "group"}},
+	},
+}
+
+func dns(entries []Entry) []string {
+	var dns []string
+	for _, e := range entries {
+		dns = append(dns, e.DN)
+	}
+	return dns
+}
+
+func TestStaticSearch(t *testing.T) {
+	tests := []struct {
+		baseDN string
+		filter string
+		want   []string
+	}{
+		{"", "(objectClass=group)", []string{"CN=Printers,OU=Staff,DC=example,DC=com"}},
+		{"", "(OBJECTCLASS=Group)", []string{"CN=Printers,OU=Staff,DC=example,DC=com"}},
+		{"OU=IT,OU=Staff,DC=example,DC=com", DefaultFilter, []string{"CN=Bruce Wayne,OU=IT,OU=Staff,DC=example,DC=com"}},
+		{"", "(&(objectClass=person)(!(mail=*)))", []string{"CN=No Mail,OU=IT,OU=Staff,DC=example,DC=com"}},
+		{"", "(|(sn=doe)(givenName=Bruce))", []string{
+			"CN=Jane Doe,OU=Finance,OU=Staff,DC=example,DC=com",
+			"CN=Bruce Wayne,OU=IT,OU=Staff,DC=example,DC=com",
+		}},
+		{"", "(mail=j*@EXAMPLE.com)", []string{
+			"CN=Jane Doe,OU=Finance,OU=Staff,DC=example,DC=com",
+			"CN=John Smith,OU=Finance,OU=Staff,DC=example,DC=com",
+		}},
+		{"", "(mail=*smi*)", []string{"CN=John Smith,OU=Finance,OU=Staff,DC=example,DC=com"}},
+		{"", "(mail=*wayne)", nil},
+		{"OU=Staff,DC=example,DC=com", "(department=engineering)", []string{
+			"CN=Bruce Wayne,OU=IT,OU=Staff,DC=example,DC=com",
+			"CN=No Mail,OU=IT,OU=Staff,DC=example,DC=com",
+		}},
+	}
+	for _, tt := range tests {
+		entries, err := staff.Search(context.Background(), tt.baseDN, tt.filter, []string{"mail"})
+		if err != nil {
+			t.Errorf("%s under %q: %v", tt.filter, tt.baseDN, err)
+			continue
+		}
+		if got := dns(entries); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s under %q: got %q, want %q", tt.filter, tt.baseDN, got, tt.want)
+		}
+	}
+}
+
+func TestStaticSearchAttributes(t *testing.T) {
+	entries, err := staff.Search(context.Background(), "", "(sn=Doe)", []string{"MAIL", "cn"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{{
+		DN:         "CN=Jane Doe,OU=Finance,OU=Staff,DC=example,DC=com",
+		Attributes: map[string][]string{"mail": {"JDoe@example.com"}},
+	}}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("got %+v, want %+v", entries, want)
+	}
+
+	// The entries returned are copies.
+	entries[0].Attributes["mail"][0] = "changed@example.com"
+	if got := staff[0].Get("mail"); got != "JDoe@example.com" {
+		t.Errorf("changing a result changed the directory to %q", got)
+	}
+}
+
+func TestStaticSearchErrors(t *testing.T) {
+	for _, filter := range []string{"(mail=", "(mail>=a)"} {
+		if _, err := staff.Search(context.Background(), "", filter, nil); err == nil {
+			t.Errorf("%s: got no error", filter)
+		}
+	}
+}
+
+func TestUnderBase(t *testing.T) {
+	tests := []struct {
+		dn, baseDN string
+		want       bool
+	}{
+		{"CN=Jane Doe,OU=Finance,DC=example,DC=com", "", true},
+		{"CN=Jane Doe,OU=Finance,DC=example,DC=com", "DC=example,DC=com", true},
+		{"CN=Jane Doe,OU=Finance,DC=example,DC=com", "ou=finance,dc=EXAMPLE,dc=com", true},
+		{"CN=Jane Doe,OU=Finance,DC=example,DC=com", "OU=Finance, DC=example, DC=com", true},
+		{"OU=Finance,DC=example,DC=com", "OU=Finance,DC=example,DC=com", true},
+		{"CN=Jane Doe,OU=Finance,DC=example,DC=com", "OU=IT,DC=example,DC=com", false},
+		{"CN=Jane Doe,OU=Finance,DC=example,DC=com", "CN=Jane Doe,OU=Finance,DC=example,DC=com,DC=org", false},
+		{"OU=Finance,DC=example,DC=com", "CN=Jane Doe,OU=Finance,DC=example,DC=com", false},
+		{"not a dn", "DC=example,DC=com", false},
+		{"CN=Jane Doe,DC=example,DC=com", "not a dn", false},
+	}
+	for _, tt := range tests {
+		if got := underBase(tt.dn, tt.baseDN); got != tt.want {
+			t.Errorf("underBase(%q, %q) = %v, want %v", tt.dn, tt.baseDN, got, tt.want)
+		}
+	}
+}
+
+func TestEntryOU(t *testing.T) {
+	tests := []struct {
+		dn, want string
+	}{
+		{"CN=Jane Doe,OU=Finance,OU=Staff,DC=example,DC=com", "Finance"},
+		{"cn=Jane Doe,ou=Finance,dc=example,dc=com", "Finance"},
+		{`CN=Doe\, Jane,OU=Sales\, EMEA,DC=example,DC=com`, "Sales, EMEA"},
+		{"CN=Jane Doe,DC=example,DC=com", ""},
+		{"", ""},
+		{"not a dn", ""},
+	}
+	for _, tt := range tests {
+		if got := (Entry{DN: tt.dn}).OU(); got != tt.want {
+			t.Errorf("OU of %q = %q, want %q", tt.dn, got, tt.want)
+		}
+	}
+}
+
+// groupTargets returns the emails of the targets of each group in gophish.
+func groupTargets(t *testing.T, groups gophish.GroupsAPI) map[string][]string {
+	t.Helper()
+	list, err := groups.ListGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	targets := make(map[string][]string)
+	for _, g := range list {
+		for _, target := range g.Targets {
+			targets[g.Name] = append(targets[g.Name], target.Email)
+		}
+	}
+	return targets
+}
+
+func TestImportByAttribute(t *testing.T) {
+	srv := gophishtest.NewServer("token")
+	defer srv.Close()
+	client := srv.Client()
+
+	result, err := Import(context.Background(), staff, client.Groups, ImportOptions{
+		BaseDN: "OU=Staff,DC=example,DC=com",
+		// DefaultFilter would leave out entries without mail.
+		Filter:      "(objectClass=person)",
+		GroupBy:     "department",
+		Group:       "Other",
+		GroupPrefix: "LDAP - ",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, synced := range result.Groups {
+		names = append(names, synced.Group.Name)
+		if !synced.Created {
+			t.Errorf("%s: want it created", synced.Group.Name)
+		}
+	}
+	if want := []string{"LDAP - Accounting", "LDAP - Engineering", "LDAP - Other"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got groups %q, want %q", names, want)
+	}
+	want := map[string][]string{
+		"LDAP - Accounting":  {"jdoe@example.com"},
+		"LDAP - Engineering": {"bwayne@example.com"},
+		// John Smith has no department.
+		"LDAP - Other": {"jsmith@example.com"},
+	}
+	if got := groupTargets(t, client.Groups); !reflect.DeepEqual(got, want) {
+		t.Errorf("got groups %v, want %v", got, want)
+	}
+	wantSkipped := []SkippedEntry{
+		{DN: "CN=No Mail,OU=IT,OU=Staff,DC=example,DC=com", Reason: "no email address"},
+	}
+	if !reflect.DeepEqual(result.Skipped, wantSkipped) {
+		t.Errorf("got skipped %+v, want %+v", result.Skipped, wantSkipped)
+	}
+
+	created := result.Groups[0].Group
+	if want := (gophish.Target{Email: "jdoe@example.com", FirstName: "Jane", LastName: "Doe"}); !reflect.DeepEqual(created.Targets, []gophish.Target{want}) {
+		t.Errorf("got targets %+v, want %+v", created.Targets, want)
+	}
+}
+
+func TestImportByOU(t *testing.T) {
+	srv := gophishtest.NewServer("token")
+	defer srv.Close()
+	client := srv.Client()
+
+	result, err := Import(context.Background(), staff, client.Groups, ImportOptions{
+		GroupBy: GroupByOU,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"Contractors": {"admin@example.com"},
+		"Finance":     {"jdoe@example.com", "jsmith@example.com"},
+		"IT":          {"bwayne@example.com"},
+	}
+	if got := groupTargets(t, client.Groups); !reflect.DeepEqual(got, want) {
+		t.Errorf("got groups %v, want %v", got, want)
+	}
+	if len(result.Skipped) != 0 {
+		t.Errorf("got skipped %+v, want the entry without mail filtered out", result.Skipped)
+	}
+}
+
+func TestImportSkipsWithoutGroup(t *testing.T) {
+	srv := gophishtest.NewServer("token")
+	defer srv.Close()
+	client := srv.Client()
+
+	dir := Static{
+		person("CN=Jane Doe,OU=Finance,DC=example,DC=com", "jdoe@example.com", "Jane", "Doe", "Accounting"),
+		person("CN=John Smith,OU=Finance,DC=example,DC=com", "jsmith@example.com", "John", "Smith", ""),
+		person("CN=Jane Smith,OU=Finance,DC=example,DC=com", "not an email", "Jane", "Smith", "Accounting"),
+		person("CN=Jane Doe (2),OU=Finance,DC=example,DC=com", "JDOE@example.com", "Jane", "Doe", "Accounting"),
+	}
+	result, err := Import(context.Background(), dir, client.Groups, ImportOptions{GroupBy: "department"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SkippedEntry{
+		{DN: "CN=John Smith,OU=Finance,DC=example,DC=com", Reason: "no department to group by"},
+		{DN: "CN=Jane Smith,OU=Finance,DC=example,DC=com", Reason: `invalid email address "not an email"`},
+		{DN: "CN=Jane Doe (2),OU=Finance,DC=example,DC=com", Reason: `duplicate email address "jdoe@example.com" in group "Accounting"`},
+	}
+	if !reflect.DeepEqual(result.Skipped, want) {
+		t.Errorf("got skipped %+v, want %+v", result.Skipped, want)
+	}
+	if got := groupTargets(t, client.Groups); !reflect.DeepEqual(got, map[string][]string{"Accounting": {"jdoe@example.com"}}) {
+		t.Errorf("got groups %v", got)
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	srv := gophishtest.NewServer("token")
+	defer srv.Close()
+	client := srv.Client()
+
+	if _, err := client.Groups.CreateGroup(&gophish.Group{
+		Name:    "Finance",
+		Targets: []gophish.Target{{Email: "jdoe@example.com", FirstName: "Jane", LastName: "Doe"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Import(context.Background(), staff, client.Groups, ImportOptions{
+		BaseDN:  "OU=Staff,DC=example,DC=com",
+		GroupBy: GroupByOU,
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Groups) != 2 {
+		t.Fatalf("got %d groups, want 2", len(result.Groups))
+	}
+	finance, it := result.Groups[0], result.Groups[1]
+	if finance.Created || finance.Diff.Unchanged != 1 || len(finance.Diff.Added) != 1 || finance.Diff.Added[0].Email != "jsmith@example.com" {
+		t.Errorf("got %+v with diff %+v, want John Smith added", finance, finance.Diff)
+	}
+	if !it.Created || it.Group.ID != 0 || len(it.Diff.Added) != 1 {
+		t.Errorf("got %+v with diff %+v, want IT to be created", it, it.Diff)
+	}
+
+	want := map[string][]string{"Finance": {"jdoe@example.com"}}
+	if got := groupTargets(t, client.Groups); !reflect.DeepEqual(got, want) {
+		t.Errorf("got groups %v after a dry run, want %v", got, want)
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	srv := gophishtest.NewServer("token")
+	defer srv.Close()
+	client := srv.Client()
+
+	for _, opts := range []ImportOptions{
+		{},
+		{Group: "Staff", Attributes: &AttributeMap{FirstName: "givenName"}},
+		{Group: "Staff", Filter: "(mail="},
+	} {
+		if _, err := Import(context.Background(), staff, client.Groups, opts); err == nil {
+			t.Errorf("%+v: got no error", opts)
+		}
+	}
+}
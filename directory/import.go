@@ -0,0 +1,194 @@
+package directory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/ttacon/gophish"
+)
+
+// DefaultFilter matches user entries with an email address.
+const DefaultFilter = "(&(objectClass=person)(mail=*))"
+
+// GroupByOU groups entries by the organizational unit they're in, rather
+// than by an attribute.
+const GroupByOU = "ou"
+
+// AttributeMap is which directory attributes are read into each Target field.
+type AttributeMap struct {
+	Email     string
+	FirstName string
+	LastName  string
+	Position  string
+}
+
+// DefaultAttributes is the standard inetOrgPerson/Active Directory mapping.
+var DefaultAttributes = AttributeMap{
+	Email:     "mail",
+	FirstName: "givenName",
+	LastName:  "sn",
+	Position:  "title",
+}
+
+// Target maps an entry to a gophish target.
+func (m AttributeMap) Target(e Entry) gophish.Target {
+	return gophish.Target{
+		Email:     strings.ToLower(strings.TrimSpace(e.Get(m.Email))),
+		FirstName: strings.TrimSpace(e.Get(m.FirstName)),
+		LastName:  strings.TrimSpace(e.Get(m.LastName)),
+		Position:  strings.TrimSpace(e.Get(m.Position)),
+	}
+}
+
+func (m AttributeMap) attributes() []string {
+	var attrs []string
+	for _, attr := range []string{m.Email, m.FirstName, m.LastName, m.Position} {
+		if attr != "" {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	// BaseDN is where in the directory to search, i.e.
+	// "OU=Staff,DC=example,DC=com".
+	BaseDN string
+
+	// Filter selects entries to import. It defaults to DefaultFilter.
+	Filter string
+
+	// Attributes defaults to DefaultAttributes. Empty fields are left
+	// unmapped, except Email which is required.
+	Attributes *AttributeMap
+
+	// GroupBy is the attribute (i.e. "department") whose value names the
+	// group each entry is imported into, or GroupByOU. If it's empty, all
+	// entries are imported into the group named Group.
+	GroupBy string
+
+	// Group is the group to import into when GroupBy is empty. When
+	// GroupBy is set, entries without a value for it are imported into
+	// Group, or skipped if Group is empty too.
+	Group string
+
+	// GroupPrefix is prepended to every group name, i.e. "LDAP - ", to keep
+	// imported groups apart from hand made ones.
+	GroupPrefix string
+
+	// DryRun computes what would change without changing anything in
+	// gophish.
+	DryRun bool
+}
+
+// SkippedEntry is a directory entry that wasn't imported.
+type SkippedEntry struct {
+	DN     string
+	Reason string
+}
+
+// ImportResult is the outcome of Import.
+type ImportResult struct {
+	// Groups are the results of syncing each group, ordered by name.
+	Groups []*gophish.SyncResult
+
+	Skipped []SkippedEntry
+}
+
+// Import searches dir and syncs the matching entries into gophish groups, so
+// each group has exactly the entries the directory has for it: groups are
+// created if missing and updated if they've drifted. Groups that no longer
+// have any entries are left alone.
+func Import(ctx context.Context, dir Searcher, groups gophish.GroupsAPI, opts ImportOptions) (*ImportResult, error) {
+	if opts.GroupBy == "" && opts.Group == "" {
+		return nil, errors.New("directory: either a group or an attribute to group by is required")
+	}
+
+	attrs := DefaultAttributes
+	if opts.Attributes != nil {
+		attrs = *opts.Attributes
+	}
+	if attrs.Email == "" {
+		return nil, errors.New("directory: an email attribute is required")
+	}
+	filter := opts.Filter
+	if filter == "" {
+		filter = DefaultFilter
+	}
+
+	requested := attrs.attributes()
+	if opts.GroupBy != "" && opts.GroupBy != GroupByOU {
+		requested = append(requested, opts.GroupBy)
+	}
+
+	entries, err := dir.Search(ctx, opts.BaseDN, filter, requested)
+	if err != nil {
+		return nil, fmt.Errorf("directory: searching %q: %v", opts.BaseDN, err)
+	}
+
+	result := &ImportResult{}
+	byGroup := make(map[string][]Entry)
+	seen := make(map[string]map[string]bool)
+	for _, e := range entries {
+		group := opts.Group
+		if opts.GroupBy == GroupByOU {
+			if ou := e.OU(); ou != "" {
+				group = ou
+			}
+		} else if opts.GroupBy != "" {
+			if v := strings.TrimSpace(e.Get(opts.GroupBy)); v != "" {
+				group = v
+			}
+		}
+		if group == "" {
+			result.Skipped = append(result.Skipped, SkippedEntry{
+				DN:     e.DN,
+				Reason: fmt.Sprintf("no %s to group by", opts.GroupBy),
+			})
+			continue
+		}
+		group = opts.GroupPrefix + group
+
+		email := attrs.Target(e).Email
+		if email == "" {
+			result.Skipped = append(result.Skipped, SkippedEntry{DN: e.DN, Reason: "no email address"})
+			continue
+		}
+		if _, err := mail.ParseAddress(email); err != nil {
+			result.Skipped = append(result.Skipped, SkippedEntry{
+				DN:     e.DN,
+				Reason: fmt.Sprintf("invalid email address %q", email),
+			})
+			continue
+		}
+		if seen[group] == nil {
+			seen[group] = make(map[string]bool)
+		}
+		if seen[group][email] {
+			result.Skipped = append(result.Skipped, SkippedEntry{
+				DN:     e.DN,
+				Reason: fmt.Sprintf("duplicate email address %q in group %q", email, group),
+			})
+			continue
+		}
+		seen[group][email] = true
+		byGroup[group] = append(byGroup[group], e)
+	}
+
+	for _, name := range sortedKeys(byGroup) {
+		targets := make([]gophish.Target, 0, len(byGroup[name]))
+		for _, e := range byGroup[name] {
+			targets = append(targets, attrs.Target(e))
+		}
+		synced, err := groups.SyncGroupContext(ctx, name, targets, gophish.SyncOptions{DryRun: opts.DryRun})
+		if err != nil {
+			return result, err
+		}
+		result.Groups = append(result.Groups, synced)
+	}
+	return result, nil
+}
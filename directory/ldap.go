@@ -0,0 +1,117 @@
+package directory
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// DefaultPageSize is the number of entries requested per page when searching
+// an LDAP server, which keeps large directories under the server's size
+// limit.
+const DefaultPageSize = 500
+
+// LDAPConfig describes how to connect to an LDAP server.
+type LDAPConfig struct {
+	// URL is the server to connect to, i.e. "ldaps://dc1.example.com:636".
+	URL string
+
+	// BindDN and BindPassword are the credentials to bind with. The
+	// connection is left anonymous if BindDN is empty.
+	BindDN       string
+	BindPassword string
+
+	// StartTLS upgrades an ldap:// connection to TLS before binding.
+	StartTLS bool
+
+	// TLSConfig is used for ldaps:// and StartTLS connections.
+	TLSConfig *tls.Config
+
+	// PageSize overrides DefaultPageSize.
+	PageSize uint32
+}
+
+// LDAP is a Searcher backed by an LDAP server.
+type LDAP struct {
+	conn     *ldap.Conn
+	pageSize uint32
+}
+
+// DialLDAP connects and binds to the LDAP server described by config.
+func DialLDAP(config LDAPConfig) (*LDAP, error) {
+	var opts []ldap.DialOpt
+	if config.TLSConfig != nil {
+		opts = append(opts, ldap.DialWithTLSConfig(config.TLSConfig))
+	}
+	conn, err := ldap.DialURL(config.URL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.StartTLS {
+		tlsConfig := config.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if config.BindDN != "" {
+		if err := conn.Bind(config.BindDN, config.BindPassword); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	pageSize := config.PageSize
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	return &LDAP{conn: conn, pageSize: pageSize}, nil
+}
+
+// Close closes the connection to the server.
+func (l *LDAP) Close() {
+	l.conn.Close()
+}
+
+// Search implements Searcher with a paged subtree search. The context's
+// deadline, if any, is used as the request timeout.
+func (l *LDAP) Search(ctx context.Context, baseDN, filter string, attrs []string) ([]Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	timeLimit := 0
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout := time.Until(deadline)
+		l.conn.SetTimeout(timeout)
+		timeLimit = int(timeout / time.Second)
+	}
+
+	req := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		0, timeLimit, false,
+		filter, attrs, nil,
+	)
+	res, err := l.conn.SearchWithPaging(req, l.pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		entry := Entry{DN: e.DN, Attributes: make(map[string][]string, len(e.Attributes))}
+		for _, attr := range e.Attributes {
+			entry.Attributes[attr.Name] = attr.Values
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,96 @@
+package gophish
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize bounds how much of an error response we read when building
+// an APIError.
+const maxErrorBodySize = 64 * 1024
+
+// APIError is returned by every service method when the gophish API responds
+// with a non-2xx status code. Gophish reports failures with a JSON body of
+// the form {"success": false, "message": "...", "data": null}, which is
+// decoded into Success and Message.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Method     string `json:"-"`
+	Path       string `json:"-"`
+	Success    bool   `json:"success"`
+	Message    string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf(
+		"gophish: %s %s: %d %s",
+		e.Method,
+		e.Path,
+		e.StatusCode,
+		msg,
+	)
+}
+
+// newAPIError builds an APIError from the given response, consuming and
+// closing its body.
+func newAPIError(method, path string, resp *http.Response) *APIError {
+	defer resp.Body.Close()
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Path:       path,
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil || len(data) == 0 {
+		return apiErr
+	}
+	if err := json.Unmarshal(data, apiErr); err != nil {
+		// Not every failure comes from gophish itself (i.e. reverse proxies
+		// in front of it), so fall back to the raw body.
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+	return apiErr
+}
+
+// IsNotFound reports whether err is an APIError for a missing resource.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is an APIError caused by a missing or
+// invalid API token.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an APIError caused by the API token not
+// having permission to perform the request.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsBadRequest reports whether err is an APIError caused by gophish rejecting
+// the request payload (i.e. a validation failure).
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+func hasStatus(err error, code int) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == code
+}
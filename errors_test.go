@@ -0,0 +1,81 @@
+package gophish_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ttacon/gophish"
+)
+
+func TestAPIErrorFromGophish(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	_, err := client.Templates.GetTemplate(42)
+	if !gophish.IsNotFound(err) {
+		t.Fatalf("got error %v, want not found", err)
+	}
+	if gophish.IsBadRequest(err) || gophish.IsUnauthorized(err) || gophish.IsForbidden(err) {
+		t.Errorf("%v matches another status", err)
+	}
+
+	var apiErr *gophish.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got %T, want *gophish.APIError", err)
+	}
+	want := gophish.APIError{
+		StatusCode: http.StatusNotFound,
+		Method:     "GET",
+		Path:       "/api/templates/42",
+		Message:    "Template not found",
+	}
+	if *apiErr != want {
+		t.Errorf("got %+v, want %+v", *apiErr, want)
+	}
+	if got, want := err.Error(), "gophish: GET /api/templates/42: 404 Template not found"; got != want {
+		t.Errorf("got message %q, want %q", got, want)
+	}
+
+	// Wrapped errors are still recognized.
+	if !gophish.IsNotFound(fmt.Errorf("loading template: %w", err)) {
+		t.Error("wrapped error isn't recognized as not found")
+	}
+
+	_, err = client.Templates.CreateTemplate(&gophish.Template{Name: "Empty"})
+	if !gophish.IsBadRequest(err) {
+		t.Fatalf("got error %v, want bad request", err)
+	}
+	if !errors.As(err, &apiErr) || apiErr.Message != "Need to specify at least plaintext or HTML content" {
+		t.Errorf("got %v, want gophish's validation message", err)
+	}
+}
+
+func TestAPIErrorFromProxy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprintln(w, "upstream unavailable")
+	}))
+	defer srv.Close()
+
+	client := gophish.NewClient(srv.URL, "token")
+	_, err := client.Campaigns.GetCampaign(1)
+
+	var apiErr *gophish.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got %v, want *gophish.APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusBadGateway || apiErr.Message != "upstream unavailable" {
+		t.Errorf("got %+v, want a 502 with the raw body as message", *apiErr)
+	}
+}
+
+func TestIsNotFoundOtherErrors(t *testing.T) {
+	for _, err := range []error{nil, errors.New("not found")} {
+		if gophish.IsNotFound(err) {
+			t.Errorf("%v is reported as not found", err)
+		}
+	}
+}
@@ -3,8 +3,11 @@ module github.com/ttacon/gophish
 go 1.13
 
 require (
-	github.com/kr/pretty v0.2.0 // indirect
-	github.com/kr/text v0.2.0 // indirect
-	github.com/ttacon/pretty v0.0.0-20140822010550-4869e1157de7
+	github.com/go-asn1-ber/asn1-ber v1.3.1
+	github.com/go-ldap/ldap/v3 v3.1.10
+	github.com/golang/mock v1.4.3
 	github.com/urfave/cli v1.22.4
+	golang.org/x/sys v0.7.0 // indirect
+	golang.org/x/term v0.0.0-20210422114643-f5beecf764ed
+	gopkg.in/yaml.v2 v2.2.8
 )
@@ -2,59 +2,197 @@ package gophish
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
 )
 
-func NewClient(host, token string) *Client {
+// New creates a client for the gophish installation at host, authenticating
+// with the given API token. An error is returned if host isn't a valid
+// http(s) URL or if any of the options fail to apply.
+func New(host, token string, opts ...Option) (*Client, error) {
+	service, err := newService(host, token, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(service), nil
+}
+
+// NewClient is like New, but rather than failing up front, any configuration
+// error is returned from every request made with the client.
+func NewClient(host, token string, opts ...Option) *Client {
+	service, err := newService(host, token, opts)
+	if err != nil {
+		service.err = err
+	}
+	return newClient(service)
+}
+
+func newService(host, token string, opts []Option) (Service, error) {
 	service := Service{
 		Host:  host,
 		Token: token,
 	}
+	for _, opt := range opts {
+		if err := opt(&service); err != nil {
+			return service, err
+		}
+	}
+
+	host, err := parseBaseURL(service.Host)
+	if err != nil {
+		return service, err
+	}
+	service.Host = host
+	return service, nil
+}
+
+func newClient(service Service) *Client {
 	return &Client{
-		SendingProfiles: SendingProfilesService{service},
-		Templates:       TemplatesService{service},
-		LandingPages:    LandingPagesService{service},
-		Groups:          GroupsService{service},
-		Campaigns:       CampaignsService{service},
+		SendingProfiles: &SendingProfilesService{service},
+		Templates:       &TemplatesService{service},
+		LandingPages:    &LandingPagesService{service},
+		Groups:          &GroupsService{service},
+		Campaigns:       &CampaignsService{service},
 	}
 }
 
+// Client groups the operations on every gophish resource. Clients created by
+// New and NewClient are backed by the concrete services, but a Client can
+// also be built from mocks (or any other implementation) of the interfaces.
 type Client struct {
-	SendingProfiles SendingProfilesService
-	Templates       TemplatesService
-	LandingPages    LandingPagesService
-	Groups          GroupsService
-	Campaigns       CampaignsService
+	SendingProfiles SendingProfilesAPI
+	Templates       TemplatesAPI
+	LandingPages    LandingPagesAPI
+	Groups          GroupsAPI
+	Campaigns       CampaignsAPI
 }
 
 type Service struct {
 	Host  string
 	Token string
+
+	// HTTPClient is the client used to issue requests, http.DefaultClient is
+	// used if it's nil.
+	HTTPClient *http.Client
+
+	// UserAgent, if set, is sent as the User-Agent header of every request.
+	UserAgent string
+
+	// RetryPolicy controls whether and how failed requests are retried.
+	RetryPolicy RetryPolicy
+
+	// Limiter, if set, throttles every request (including retries).
+	Limiter *Limiter
+
+	// Middleware wraps the transport of every request, the first middleware
+	// being the outermost.
+	Middleware []Middleware
+
+	// err is a configuration error from NewClient, returned by every request.
+	err error
 }
 
+// MakeRequest issues a request against the gophish API, JSON encoding the
+// payload (if one is given) as the request body. If gophish responds with a
+// non-2xx status code, the response body is consumed and an *APIError is
+// returned instead.
 func (s Service) MakeRequest(method, path string, payload interface{}) (*http.Response, error) {
-	var r io.Reader
+	return s.MakeRequestContext(context.Background(), method, path, payload)
+}
+
+// MakeRequestContext is like MakeRequest, but the request is bound to the
+// given context, so it is aborted once the context is cancelled or its
+// deadline passes.
+func (s Service) MakeRequestContext(ctx context.Context, method, path string, payload interface{}) (*http.Response, error) {
+	var data []byte
 	if payload != nil {
-		data, err := json.Marshal(payload)
-		if err != nil {
+		var err error
+		if data, err = json.Marshal(payload); err != nil {
 			return nil, err
 		}
-		r = bytes.NewBuffer(data)
+	}
+	return s.do(ctx, method, path, "application/json", data)
+}
+
+// do issues the request, retrying it according to the service's
+// RetryPolicy. The body (of the given content type) is optional.
+func (s Service) do(ctx context.Context, method, path, contentType string, body []byte) (*http.Response, error) {
+	if s.err != nil {
+		return nil, s.err
 	}
 
-	path = s.Host + path
-	req, err := http.NewRequest(method, path, r)
+	var (
+		resp *http.Response
+		err  error
+	)
+	for attempt := 0; ; attempt++ {
+		resp, err = s.send(ctx, method, path, contentType, body)
+		if !s.RetryPolicy.shouldRetry(ctx, method, attempt, resp, err) {
+			break
+		}
+
+		wait := s.RetryPolicy.backoff(attempt, resp)
+		if resp != nil {
+			discard(resp)
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newAPIError(method, path, resp)
+	}
+	return resp, nil
+}
 
-	req.Header.Add("Authorization", s.Token)
+// send makes a single attempt at the request.
+func (s Service) send(ctx context.Context, method, path, contentType string, body []byte) (*http.Response, error) {
+	if s.Limiter != nil {
+		release, err := s.Limiter.Wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
 
-	resp, err := http.DefaultClient.Do(req)
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.Host+path, r)
 	if err != nil {
 		return nil, err
 	}
-	return resp, nil
+
+	req.Header.Add("Authorization", s.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if s.UserAgent != "" {
+		req.Header.Set("User-Agent", s.UserAgent)
+	}
+
+	return s.transport().RoundTrip(req)
+}
+
+// transport returns the service's HTTP client wrapped in its middleware.
+func (s Service) transport() http.RoundTripper {
+	var rt http.RoundTripper = RoundTripperFunc(s.httpClient().Do)
+	for i := len(s.Middleware) - 1; i >= 0; i-- {
+		rt = s.Middleware[i](rt)
+	}
+	return rt
+}
+
+func (s Service) httpClient() *http.Client {
+	if s.HTTPClient != nil {
+		return s.HTTPClient
+	}
+	return http.DefaultClient
 }
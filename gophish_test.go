@@ -0,0 +1,76 @@
+package gophish_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/gophishtest"
+)
+
+// now is the time of the fake servers used in tests.
+var now = time.Date(2020, 5, 8, 15, 7, 1, 0, time.UTC)
+
+func newTestServer(t *testing.T) (*gophishtest.Server, *gophish.Client) {
+	t.Helper()
+	srv := gophishtest.NewServer("token", gophishtest.WithClock(func() time.Time {
+		return now
+	}))
+	return srv, srv.Client()
+}
+
+func TestUnauthorized(t *testing.T) {
+	srv, _ := newTestServer(t)
+	defer srv.Close()
+
+	tests := []struct {
+		token   string
+		message string
+	}{
+		{"", "API Key not set"},
+		{"wrong", "Invalid API Key"},
+	}
+	for _, tt := range tests {
+		client := gophish.NewClient(srv.URL, tt.token)
+		_, err := client.Templates.ListTemplates()
+		if !gophish.IsUnauthorized(err) {
+			t.Errorf("token %q: got error %v, want unauthorized", tt.token, err)
+			continue
+		}
+
+		var apiErr *gophish.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("got %T, want *gophish.APIError", err)
+		}
+		want := gophish.APIError{
+			StatusCode: http.StatusUnauthorized,
+			Method:     "GET",
+			Path:       "/api/templates",
+			Message:    tt.message,
+		}
+		if *apiErr != want {
+			t.Errorf("token %q: got %+v, want %+v", tt.token, *apiErr, want)
+		}
+	}
+}
+
+func TestNewValidatesBaseURL(t *testing.T) {
+	for _, host := range []string{"", "gophish.example.com", "ftp://gophish", "https://gophish?x=1"} {
+		if _, err := gophish.New(host, "token"); err == nil {
+			t.Errorf("%q: got no error", host)
+		}
+
+		// NewClient defers the error to every request.
+		client := gophish.NewClient(host, "token")
+		if _, err := client.Groups.ListGroups(); err == nil {
+			t.Errorf("%q: got no error from a request", host)
+		}
+	}
+
+	client, err := gophish.New("https://gophish.example.com/admin/", "token")
+	if err != nil || client == nil {
+		t.Errorf("got error %v for a valid base URL", err)
+	}
+}
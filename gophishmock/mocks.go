@@ -0,0 +1,1314 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package gophishmock is a generated GoMock package.
+package gophishmock
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	gophish "github.com/ttacon/gophish"
+	io "io"
+	reflect "reflect"
+	time "time"
+)
+
+// MockSendingProfilesAPI is a mock of SendingProfilesAPI interface
+type MockSendingProfilesAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSendingProfilesAPIMockRecorder
+}
+
+// MockSendingProfilesAPIMockRecorder is the mock recorder for MockSendingProfilesAPI
+type MockSendingProfilesAPIMockRecorder struct {
+	mock *MockSendingProfilesAPI
+}
+
+// NewMockSendingProfilesAPI creates a new mock instance
+func NewMockSendingProfilesAPI(ctrl *gomock.Controller) *MockSendingProfilesAPI {
+	mock := &MockSendingProfilesAPI{ctrl: ctrl}
+	mock.recorder = &MockSendingProfilesAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSendingProfilesAPI) EXPECT() *MockSendingProfilesAPIMockRecorder {
+	return m.recorder
+}
+
+// ListSendingProfiles mocks base method
+func (m *MockSendingProfilesAPI) ListSendingProfiles() ([]gophish.SendingProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSendingProfiles")
+	ret0, _ := ret[0].([]gophish.SendingProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSendingProfiles indicates an expected call of ListSendingProfiles
+func (mr *MockSendingProfilesAPIMockRecorder) ListSendingProfiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSendingProfiles", reflect.TypeOf((*MockSendingProfilesAPI)(nil).ListSendingProfiles))
+}
+
+// ListSendingProfilesContext mocks base method
+func (m *MockSendingProfilesAPI) ListSendingProfilesContext(ctx context.Context) ([]gophish.SendingProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSendingProfilesContext", ctx)
+	ret0, _ := ret[0].([]gophish.SendingProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSendingProfilesContext indicates an expected call of ListSendingProfilesContext
+func (mr *MockSendingProfilesAPIMockRecorder) ListSendingProfilesContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSendingProfilesContext", reflect.TypeOf((*MockSendingProfilesAPI)(nil).ListSendingProfilesContext), ctx)
+}
+
+// GetSendingProfile mocks base method
+func (m *MockSendingProfilesAPI) GetSendingProfile(id int) (*gophish.SendingProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSendingProfile", id)
+	ret0, _ := ret[0].(*gophish.SendingProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSendingProfile indicates an expected call of GetSendingProfile
+func (mr *MockSendingProfilesAPIMockRecorder) GetSendingProfile(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSendingProfile", reflect.TypeOf((*MockSendingProfilesAPI)(nil).GetSendingProfile), id)
+}
+
+// GetSendingProfileContext mocks base method
+func (m *MockSendingProfilesAPI) GetSendingProfileContext(ctx context.Context, id int) (*gophish.SendingProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSendingProfileContext", ctx, id)
+	ret0, _ := ret[0].(*gophish.SendingProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSendingProfileContext indicates an expected call of GetSendingProfileContext
+func (mr *MockSendingProfilesAPIMockRecorder) GetSendingProfileContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSendingProfileContext", reflect.TypeOf((*MockSendingProfilesAPI)(nil).GetSendingProfileContext), ctx, id)
+}
+
+// CreateSendingProfile mocks base method
+func (m *MockSendingProfilesAPI) CreateSendingProfile(profile *gophish.SendingProfile) (*gophish.SendingProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSendingProfile", profile)
+	ret0, _ := ret[0].(*gophish.SendingProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSendingProfile indicates an expected call of CreateSendingProfile
+func (mr *MockSendingProfilesAPIMockRecorder) CreateSendingProfile(profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSendingProfile", reflect.TypeOf((*MockSendingProfilesAPI)(nil).CreateSendingProfile), profile)
+}
+
+// CreateSendingProfileContext mocks base method
+func (m *MockSendingProfilesAPI) CreateSendingProfileContext(ctx context.Context, profile *gophish.SendingProfile) (*gophish.SendingProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSendingProfileContext", ctx, profile)
+	ret0, _ := ret[0].(*gophish.SendingProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSendingProfileContext indicates an expected call of CreateSendingProfileContext
+func (mr *MockSendingProfilesAPIMockRecorder) CreateSendingProfileContext(ctx, profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSendingProfileContext", reflect.TypeOf((*MockSendingProfilesAPI)(nil).CreateSendingProfileContext), ctx, profile)
+}
+
+// UpdateSendingProfile mocks base method
+func (m *MockSendingProfilesAPI) UpdateSendingProfile(profile *gophish.SendingProfile) (*gophish.SendingProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSendingProfile", profile)
+	ret0, _ := ret[0].(*gophish.SendingProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSendingProfile indicates an expected call of UpdateSendingProfile
+func (mr *MockSendingProfilesAPIMockRecorder) UpdateSendingProfile(profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSendingProfile", reflect.TypeOf((*MockSendingProfilesAPI)(nil).UpdateSendingProfile), profile)
+}
+
+// UpdateSendingProfileContext mocks base method
+func (m *MockSendingProfilesAPI) UpdateSendingProfileContext(ctx context.Context, profile *gophish.SendingProfile) (*gophish.SendingProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSendingProfileContext", ctx, profile)
+	ret0, _ := ret[0].(*gophish.SendingProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSendingProfileContext indicates an expected call of UpdateSendingProfileContext
+func (mr *MockSendingProfilesAPIMockRecorder) UpdateSendingProfileContext(ctx, profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSendingProfileContext", reflect.TypeOf((*MockSendingProfilesAPI)(nil).UpdateSendingProfileContext), ctx, profile)
+}
+
+// DeleteSendingProfile mocks base method
+func (m *MockSendingProfilesAPI) DeleteSendingProfile(id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSendingProfile", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSendingProfile indicates an expected call of DeleteSendingProfile
+func (mr *MockSendingProfilesAPIMockRecorder) DeleteSendingProfile(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSendingProfile", reflect.TypeOf((*MockSendingProfilesAPI)(nil).DeleteSendingProfile), id)
+}
+
+// DeleteSendingProfileContext mocks base method
+func (m *MockSendingProfilesAPI) DeleteSendingProfileContext(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSendingProfileContext", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSendingProfileContext indicates an expected call of DeleteSendingProfileContext
+func (mr *MockSendingProfilesAPIMockRecorder) DeleteSendingProfileContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSendingProfileContext", reflect.TypeOf((*MockSendingProfilesAPI)(nil).DeleteSendingProfileContext), ctx, id)
+}
+
+// MockTemplatesAPI is a mock of TemplatesAPI interface
+type MockTemplatesAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplatesAPIMockRecorder
+}
+
+// MockTemplatesAPIMockRecorder is the mock recorder for MockTemplatesAPI
+type MockTemplatesAPIMockRecorder struct {
+	mock *MockTemplatesAPI
+}
+
+// NewMockTemplatesAPI creates a new mock instance
+func NewMockTemplatesAPI(ctrl *gomock.Controller) *MockTemplatesAPI {
+	mock := &MockTemplatesAPI{ctrl: ctrl}
+	mock.recorder = &MockTemplatesAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTemplatesAPI) EXPECT() *MockTemplatesAPIMockRecorder {
+	return m.recorder
+}
+
+// ListTemplates mocks base method
+func (m *MockTemplatesAPI) ListTemplates() ([]gophish.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates")
+	ret0, _ := ret[0].([]gophish.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates
+func (mr *MockTemplatesAPIMockRecorder) ListTemplates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockTemplatesAPI)(nil).ListTemplates))
+}
+
+// ListTemplatesContext mocks base method
+func (m *MockTemplatesAPI) ListTemplatesContext(ctx context.Context) ([]gophish.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplatesContext", ctx)
+	ret0, _ := ret[0].([]gophish.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplatesContext indicates an expected call of ListTemplatesContext
+func (mr *MockTemplatesAPIMockRecorder) ListTemplatesContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplatesContext", reflect.TypeOf((*MockTemplatesAPI)(nil).ListTemplatesContext), ctx)
+}
+
+// GetTemplate mocks base method
+func (m *MockTemplatesAPI) GetTemplate(id int) (*gophish.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", id)
+	ret0, _ := ret[0].(*gophish.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate
+func (mr *MockTemplatesAPIMockRecorder) GetTemplate(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockTemplatesAPI)(nil).GetTemplate), id)
+}
+
+// GetTemplateContext mocks base method
+func (m *MockTemplatesAPI) GetTemplateContext(ctx context.Context, id int) (*gophish.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateContext", ctx, id)
+	ret0, _ := ret[0].(*gophish.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateContext indicates an expected call of GetTemplateContext
+func (mr *MockTemplatesAPIMockRecorder) GetTemplateContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateContext", reflect.TypeOf((*MockTemplatesAPI)(nil).GetTemplateContext), ctx, id)
+}
+
+// CreateTemplate mocks base method
+func (m *MockTemplatesAPI) CreateTemplate(template *gophish.Template) (*gophish.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", template)
+	ret0, _ := ret[0].(*gophish.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate
+func (mr *MockTemplatesAPIMockRecorder) CreateTemplate(template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockTemplatesAPI)(nil).CreateTemplate), template)
+}
+
+// CreateTemplateContext mocks base method
+func (m *MockTemplatesAPI) CreateTemplateContext(ctx context.Context, template *gophish.Template) (*gophish.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplateContext", ctx, template)
+	ret0, _ := ret[0].(*gophish.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplateContext indicates an expected call of CreateTemplateContext
+func (mr *MockTemplatesAPIMockRecorder) CreateTemplateContext(ctx, template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplateContext", reflect.TypeOf((*MockTemplatesAPI)(nil).CreateTemplateContext), ctx, template)
+}
+
+// UpdateTemplate mocks base method
+func (m *MockTemplatesAPI) UpdateTemplate(template *gophish.Template) (*gophish.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", template)
+	ret0, _ := ret[0].(*gophish.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate
+func (mr *MockTemplatesAPIMockRecorder) UpdateTemplate(template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockTemplatesAPI)(nil).UpdateTemplate), template)
+}
+
+// UpdateTemplateContext mocks base method
+func (m *MockTemplatesAPI) UpdateTemplateContext(ctx context.Context, template *gophish.Template) (*gophish.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplateContext", ctx, template)
+	ret0, _ := ret[0].(*gophish.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTemplateContext indicates an expected call of UpdateTemplateContext
+func (mr *MockTemplatesAPIMockRecorder) UpdateTemplateContext(ctx, template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplateContext", reflect.TypeOf((*MockTemplatesAPI)(nil).UpdateTemplateContext), ctx, template)
+}
+
+// DeleteTemplate mocks base method
+func (m *MockTemplatesAPI) DeleteTemplate(id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate
+func (mr *MockTemplatesAPIMockRecorder) DeleteTemplate(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockTemplatesAPI)(nil).DeleteTemplate), id)
+}
+
+// DeleteTemplateContext mocks base method
+func (m *MockTemplatesAPI) DeleteTemplateContext(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplateContext", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTemplateContext indicates an expected call of DeleteTemplateContext
+func (mr *MockTemplatesAPIMockRecorder) DeleteTemplateContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplateContext", reflect.TypeOf((*MockTemplatesAPI)(nil).DeleteTemplateContext), ctx, id)
+}
+
+// ImportTemplate mocks base method
+func (m *MockTemplatesAPI) ImportTemplate(imp gophish.ImportRequest) (*gophish.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTemplate", imp)
+	ret0, _ := ret[0].(*gophish.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTemplate indicates an expected call of ImportTemplate
+func (mr *MockTemplatesAPIMockRecorder) ImportTemplate(imp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTemplate", reflect.TypeOf((*MockTemplatesAPI)(nil).ImportTemplate), imp)
+}
+
+// ImportTemplateContext mocks base method
+func (m *MockTemplatesAPI) ImportTemplateContext(ctx context.Context, imp gophish.ImportRequest) (*gophish.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTemplateContext", ctx, imp)
+	ret0, _ := ret[0].(*gophish.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTemplateContext indicates an expected call of ImportTemplateContext
+func (mr *MockTemplatesAPIMockRecorder) ImportTemplateContext(ctx, imp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTemplateContext", reflect.TypeOf((*MockTemplatesAPI)(nil).ImportTemplateContext), ctx, imp)
+}
+
+// MockLandingPagesAPI is a mock of LandingPagesAPI interface
+type MockLandingPagesAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockLandingPagesAPIMockRecorder
+}
+
+// MockLandingPagesAPIMockRecorder is the mock recorder for MockLandingPagesAPI
+type MockLandingPagesAPIMockRecorder struct {
+	mock *MockLandingPagesAPI
+}
+
+// NewMockLandingPagesAPI creates a new mock instance
+func NewMockLandingPagesAPI(ctrl *gomock.Controller) *MockLandingPagesAPI {
+	mock := &MockLandingPagesAPI{ctrl: ctrl}
+	mock.recorder = &MockLandingPagesAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockLandingPagesAPI) EXPECT() *MockLandingPagesAPIMockRecorder {
+	return m.recorder
+}
+
+// ListLandingPages mocks base method
+func (m *MockLandingPagesAPI) ListLandingPages() ([]gophish.LandingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLandingPages")
+	ret0, _ := ret[0].([]gophish.LandingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLandingPages indicates an expected call of ListLandingPages
+func (mr *MockLandingPagesAPIMockRecorder) ListLandingPages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLandingPages", reflect.TypeOf((*MockLandingPagesAPI)(nil).ListLandingPages))
+}
+
+// ListLandingPagesContext mocks base method
+func (m *MockLandingPagesAPI) ListLandingPagesContext(ctx context.Context) ([]gophish.LandingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLandingPagesContext", ctx)
+	ret0, _ := ret[0].([]gophish.LandingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLandingPagesContext indicates an expected call of ListLandingPagesContext
+func (mr *MockLandingPagesAPIMockRecorder) ListLandingPagesContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLandingPagesContext", reflect.TypeOf((*MockLandingPagesAPI)(nil).ListLandingPagesContext), ctx)
+}
+
+// GetLandingPage mocks base method
+func (m *MockLandingPagesAPI) GetLandingPage(id int) (*gophish.LandingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLandingPage", id)
+	ret0, _ := ret[0].(*gophish.LandingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLandingPage indicates an expected call of GetLandingPage
+func (mr *MockLandingPagesAPIMockRecorder) GetLandingPage(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLandingPage", reflect.TypeOf((*MockLandingPagesAPI)(nil).GetLandingPage), id)
+}
+
+// GetLandingPageContext mocks base method
+func (m *MockLandingPagesAPI) GetLandingPageContext(ctx context.Context, id int) (*gophish.LandingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLandingPageContext", ctx, id)
+	ret0, _ := ret[0].(*gophish.LandingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLandingPageContext indicates an expected call of GetLandingPageContext
+func (mr *MockLandingPagesAPIMockRecorder) GetLandingPageContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLandingPageContext", reflect.TypeOf((*MockLandingPagesAPI)(nil).GetLandingPageContext), ctx, id)
+}
+
+// CreateLandingPage mocks base method
+func (m *MockLandingPagesAPI) CreateLandingPage(landingPage *gophish.LandingPage) (*gophish.LandingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLandingPage", landingPage)
+	ret0, _ := ret[0].(*gophish.LandingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLandingPage indicates an expected call of CreateLandingPage
+func (mr *MockLandingPagesAPIMockRecorder) CreateLandingPage(landingPage interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLandingPage", reflect.TypeOf((*MockLandingPagesAPI)(nil).CreateLandingPage), landingPage)
+}
+
+// CreateLandingPageContext mocks base method
+func (m *MockLandingPagesAPI) CreateLandingPageContext(ctx context.Context, landingPage *gophish.LandingPage) (*gophish.LandingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLandingPageContext", ctx, landingPage)
+	ret0, _ := ret[0].(*gophish.LandingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLandingPageContext indicates an expected call of CreateLandingPageContext
+func (mr *MockLandingPagesAPIMockRecorder) CreateLandingPageContext(ctx, landingPage interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLandingPageContext", reflect.TypeOf((*MockLandingPagesAPI)(nil).CreateLandingPageContext), ctx, landingPage)
+}
+
+// UpdateLandingPage mocks base method
+func (m *MockLandingPagesAPI) UpdateLandingPage(landingPage *gophish.LandingPage) (*gophish.LandingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLandingPage", landingPage)
+	ret0, _ := ret[0].(*gophish.LandingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLandingPage indicates an expected call of UpdateLandingPage
+func (mr *MockLandingPagesAPIMockRecorder) UpdateLandingPage(landingPage interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLandingPage", reflect.TypeOf((*MockLandingPagesAPI)(nil).UpdateLandingPage), landingPage)
+}
+
+// UpdateLandingPageContext mocks base method
+func (m *MockLandingPagesAPI) UpdateLandingPageContext(ctx context.Context, landingPage *gophish.LandingPage) (*gophish.LandingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLandingPageContext", ctx, landingPage)
+	ret0, _ := ret[0].(*gophish.LandingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLandingPageContext indicates an expected call of UpdateLandingPageContext
+func (mr *MockLandingPagesAPIMockRecorder) UpdateLandingPageContext(ctx, landingPage interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLandingPageContext", reflect.TypeOf((*MockLandingPagesAPI)(nil).UpdateLandingPageContext), ctx, landingPage)
+}
+
+// DeleteLandingPage mocks base method
+func (m *MockLandingPagesAPI) DeleteLandingPage(id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLandingPage", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLandingPage indicates an expected call of DeleteLandingPage
+func (mr *MockLandingPagesAPIMockRecorder) DeleteLandingPage(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLandingPage", reflect.TypeOf((*MockLandingPagesAPI)(nil).DeleteLandingPage), id)
+}
+
+// DeleteLandingPageContext mocks base method
+func (m *MockLandingPagesAPI) DeleteLandingPageContext(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLandingPageContext", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLandingPageContext indicates an expected call of DeleteLandingPageContext
+func (mr *MockLandingPagesAPIMockRecorder) DeleteLandingPageContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLandingPageContext", reflect.TypeOf((*MockLandingPagesAPI)(nil).DeleteLandingPageContext), ctx, id)
+}
+
+// ImportSite mocks base method
+func (m *MockLandingPagesAPI) ImportSite(imp gophish.ImportSiteRequest) (*gophish.LandingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSite", imp)
+	ret0, _ := ret[0].(*gophish.LandingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSite indicates an expected call of ImportSite
+func (mr *MockLandingPagesAPIMockRecorder) ImportSite(imp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSite", reflect.TypeOf((*MockLandingPagesAPI)(nil).ImportSite), imp)
+}
+
+// ImportSiteContext mocks base method
+func (m *MockLandingPagesAPI) ImportSiteContext(ctx context.Context, imp gophish.ImportSiteRequest) (*gophish.LandingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSiteContext", ctx, imp)
+	ret0, _ := ret[0].(*gophish.LandingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSiteContext indicates an expected call of ImportSiteContext
+func (mr *MockLandingPagesAPIMockRecorder) ImportSiteContext(ctx, imp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSiteContext", reflect.TypeOf((*MockLandingPagesAPI)(nil).ImportSiteContext), ctx, imp)
+}
+
+// MockGroupsAPI is a mock of GroupsAPI interface
+type MockGroupsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupsAPIMockRecorder
+}
+
+// MockGroupsAPIMockRecorder is the mock recorder for MockGroupsAPI
+type MockGroupsAPIMockRecorder struct {
+	mock *MockGroupsAPI
+}
+
+// NewMockGroupsAPI creates a new mock instance
+func NewMockGroupsAPI(ctrl *gomock.Controller) *MockGroupsAPI {
+	mock := &MockGroupsAPI{ctrl: ctrl}
+	mock.recorder = &MockGroupsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockGroupsAPI) EXPECT() *MockGroupsAPIMockRecorder {
+	return m.recorder
+}
+
+// ListGroups mocks base method
+func (m *MockGroupsAPI) ListGroups() ([]gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroups")
+	ret0, _ := ret[0].([]gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroups indicates an expected call of ListGroups
+func (mr *MockGroupsAPIMockRecorder) ListGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroups", reflect.TypeOf((*MockGroupsAPI)(nil).ListGroups))
+}
+
+// ListGroupsContext mocks base method
+func (m *MockGroupsAPI) ListGroupsContext(ctx context.Context) ([]gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupsContext", ctx)
+	ret0, _ := ret[0].([]gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupsContext indicates an expected call of ListGroupsContext
+func (mr *MockGroupsAPIMockRecorder) ListGroupsContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupsContext", reflect.TypeOf((*MockGroupsAPI)(nil).ListGroupsContext), ctx)
+}
+
+// StreamGroups mocks base method
+func (m *MockGroupsAPI) StreamGroups() (*gophish.GroupIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamGroups")
+	ret0, _ := ret[0].(*gophish.GroupIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamGroups indicates an expected call of StreamGroups
+func (mr *MockGroupsAPIMockRecorder) StreamGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamGroups", reflect.TypeOf((*MockGroupsAPI)(nil).StreamGroups))
+}
+
+// StreamGroupsContext mocks base method
+func (m *MockGroupsAPI) StreamGroupsContext(ctx context.Context) (*gophish.GroupIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamGroupsContext", ctx)
+	ret0, _ := ret[0].(*gophish.GroupIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamGroupsContext indicates an expected call of StreamGroupsContext
+func (mr *MockGroupsAPIMockRecorder) StreamGroupsContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamGroupsContext", reflect.TypeOf((*MockGroupsAPI)(nil).StreamGroupsContext), ctx)
+}
+
+// GetGroup mocks base method
+func (m *MockGroupsAPI) GetGroup(id int) (*gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroup", id)
+	ret0, _ := ret[0].(*gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroup indicates an expected call of GetGroup
+func (mr *MockGroupsAPIMockRecorder) GetGroup(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockGroupsAPI)(nil).GetGroup), id)
+}
+
+// GetGroupContext mocks base method
+func (m *MockGroupsAPI) GetGroupContext(ctx context.Context, id int) (*gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupContext", ctx, id)
+	ret0, _ := ret[0].(*gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupContext indicates an expected call of GetGroupContext
+func (mr *MockGroupsAPIMockRecorder) GetGroupContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupContext", reflect.TypeOf((*MockGroupsAPI)(nil).GetGroupContext), ctx, id)
+}
+
+// CreateGroup mocks base method
+func (m *MockGroupsAPI) CreateGroup(group *gophish.Group) (*gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", group)
+	ret0, _ := ret[0].(*gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroup indicates an expected call of CreateGroup
+func (mr *MockGroupsAPIMockRecorder) CreateGroup(group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockGroupsAPI)(nil).CreateGroup), group)
+}
+
+// CreateGroupContext mocks base method
+func (m *MockGroupsAPI) CreateGroupContext(ctx context.Context, group *gophish.Group) (*gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupContext", ctx, group)
+	ret0, _ := ret[0].(*gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroupContext indicates an expected call of CreateGroupContext
+func (mr *MockGroupsAPIMockRecorder) CreateGroupContext(ctx, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupContext", reflect.TypeOf((*MockGroupsAPI)(nil).CreateGroupContext), ctx, group)
+}
+
+// UpdateGroup mocks base method
+func (m *MockGroupsAPI) UpdateGroup(group *gophish.Group) (*gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroup", group)
+	ret0, _ := ret[0].(*gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGroup indicates an expected call of UpdateGroup
+func (mr *MockGroupsAPIMockRecorder) UpdateGroup(group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockGroupsAPI)(nil).UpdateGroup), group)
+}
+
+// UpdateGroupContext mocks base method
+func (m *MockGroupsAPI) UpdateGroupContext(ctx context.Context, group *gophish.Group) (*gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroupContext", ctx, group)
+	ret0, _ := ret[0].(*gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGroupContext indicates an expected call of UpdateGroupContext
+func (mr *MockGroupsAPIMockRecorder) UpdateGroupContext(ctx, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupContext", reflect.TypeOf((*MockGroupsAPI)(nil).UpdateGroupContext), ctx, group)
+}
+
+// DeleteGroup mocks base method
+func (m *MockGroupsAPI) DeleteGroup(id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup
+func (mr *MockGroupsAPIMockRecorder) DeleteGroup(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockGroupsAPI)(nil).DeleteGroup), id)
+}
+
+// DeleteGroupContext mocks base method
+func (m *MockGroupsAPI) DeleteGroupContext(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroupContext", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGroupContext indicates an expected call of DeleteGroupContext
+func (mr *MockGroupsAPIMockRecorder) DeleteGroupContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupContext", reflect.TypeOf((*MockGroupsAPI)(nil).DeleteGroupContext), ctx, id)
+}
+
+// ImportTargets mocks base method
+func (m *MockGroupsAPI) ImportTargets(r io.Reader, filename string) ([]gophish.Target, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTargets", r, filename)
+	ret0, _ := ret[0].([]gophish.Target)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTargets indicates an expected call of ImportTargets
+func (mr *MockGroupsAPIMockRecorder) ImportTargets(r, filename interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTargets", reflect.TypeOf((*MockGroupsAPI)(nil).ImportTargets), r, filename)
+}
+
+// ImportTargetsContext mocks base method
+func (m *MockGroupsAPI) ImportTargetsContext(ctx context.Context, r io.Reader, filename string) ([]gophish.Target, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTargetsContext", ctx, r, filename)
+	ret0, _ := ret[0].([]gophish.Target)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTargetsContext indicates an expected call of ImportTargetsContext
+func (mr *MockGroupsAPIMockRecorder) ImportTargetsContext(ctx, r, filename interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTargetsContext", reflect.TypeOf((*MockGroupsAPI)(nil).ImportTargetsContext), ctx, r, filename)
+}
+
+// ImportGroup mocks base method
+func (m *MockGroupsAPI) ImportGroup(imp gophish.ImportGroupRequest) (*gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportGroup", imp)
+	ret0, _ := ret[0].(*gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportGroup indicates an expected call of ImportGroup
+func (mr *MockGroupsAPIMockRecorder) ImportGroup(imp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportGroup", reflect.TypeOf((*MockGroupsAPI)(nil).ImportGroup), imp)
+}
+
+// ImportGroupContext mocks base method
+func (m *MockGroupsAPI) ImportGroupContext(ctx context.Context, imp gophish.ImportGroupRequest) (*gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportGroupContext", ctx, imp)
+	ret0, _ := ret[0].(*gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportGroupContext indicates an expected call of ImportGroupContext
+func (mr *MockGroupsAPIMockRecorder) ImportGroupContext(ctx, imp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportGroupContext", reflect.TypeOf((*MockGroupsAPI)(nil).ImportGroupContext), ctx, imp)
+}
+
+// ListGroupSummaries mocks base method
+func (m *MockGroupsAPI) ListGroupSummaries() ([]gophish.GroupSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupSummaries")
+	ret0, _ := ret[0].([]gophish.GroupSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupSummaries indicates an expected call of ListGroupSummaries
+func (mr *MockGroupsAPIMockRecorder) ListGroupSummaries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupSummaries", reflect.TypeOf((*MockGroupsAPI)(nil).ListGroupSummaries))
+}
+
+// ListGroupSummariesContext mocks base method
+func (m *MockGroupsAPI) ListGroupSummariesContext(ctx context.Context) ([]gophish.GroupSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupSummariesContext", ctx)
+	ret0, _ := ret[0].([]gophish.GroupSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupSummariesContext indicates an expected call of ListGroupSummariesContext
+func (mr *MockGroupsAPIMockRecorder) ListGroupSummariesContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupSummariesContext", reflect.TypeOf((*MockGroupsAPI)(nil).ListGroupSummariesContext), ctx)
+}
+
+// GetGroupSummary mocks base method
+func (m *MockGroupsAPI) GetGroupSummary(id int) (*gophish.GroupSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupSummary", id)
+	ret0, _ := ret[0].(*gophish.GroupSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupSummary indicates an expected call of GetGroupSummary
+func (mr *MockGroupsAPIMockRecorder) GetGroupSummary(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupSummary", reflect.TypeOf((*MockGroupsAPI)(nil).GetGroupSummary), id)
+}
+
+// GetGroupSummaryContext mocks base method
+func (m *MockGroupsAPI) GetGroupSummaryContext(ctx context.Context, id int) (*gophish.GroupSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupSummaryContext", ctx, id)
+	ret0, _ := ret[0].(*gophish.GroupSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupSummaryContext indicates an expected call of GetGroupSummaryContext
+func (mr *MockGroupsAPIMockRecorder) GetGroupSummaryContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupSummaryContext", reflect.TypeOf((*MockGroupsAPI)(nil).GetGroupSummaryContext), ctx, id)
+}
+
+// SyncGroup mocks base method
+func (m *MockGroupsAPI) SyncGroup(name string, targets []gophish.Target, opts gophish.SyncOptions) (*gophish.SyncResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncGroup", name, targets, opts)
+	ret0, _ := ret[0].(*gophish.SyncResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncGroup indicates an expected call of SyncGroup
+func (mr *MockGroupsAPIMockRecorder) SyncGroup(name, targets, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncGroup", reflect.TypeOf((*MockGroupsAPI)(nil).SyncGroup), name, targets, opts)
+}
+
+// SyncGroupContext mocks base method
+func (m *MockGroupsAPI) SyncGroupContext(ctx context.Context, name string, targets []gophish.Target, opts gophish.SyncOptions) (*gophish.SyncResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncGroupContext", ctx, name, targets, opts)
+	ret0, _ := ret[0].(*gophish.SyncResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncGroupContext indicates an expected call of SyncGroupContext
+func (mr *MockGroupsAPIMockRecorder) SyncGroupContext(ctx, name, targets, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncGroupContext", reflect.TypeOf((*MockGroupsAPI)(nil).SyncGroupContext), ctx, name, targets, opts)
+}
+
+// SplitGroup mocks base method
+func (m *MockGroupsAPI) SplitGroup(id, n int, opts gophish.SplitOptions) ([]*gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SplitGroup", id, n, opts)
+	ret0, _ := ret[0].([]*gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SplitGroup indicates an expected call of SplitGroup
+func (mr *MockGroupsAPIMockRecorder) SplitGroup(id, n, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitGroup", reflect.TypeOf((*MockGroupsAPI)(nil).SplitGroup), id, n, opts)
+}
+
+// SplitGroupContext mocks base method
+func (m *MockGroupsAPI) SplitGroupContext(ctx context.Context, id, n int, opts gophish.SplitOptions) ([]*gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SplitGroupContext", ctx, id, n, opts)
+	ret0, _ := ret[0].([]*gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SplitGroupContext indicates an expected call of SplitGroupContext
+func (mr *MockGroupsAPIMockRecorder) SplitGroupContext(ctx, id, n, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitGroupContext", reflect.TypeOf((*MockGroupsAPI)(nil).SplitGroupContext), ctx, id, n, opts)
+}
+
+// SampleGroup mocks base method
+func (m *MockGroupsAPI) SampleGroup(id, k int, opts gophish.SampleOptions) (*gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SampleGroup", id, k, opts)
+	ret0, _ := ret[0].(*gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SampleGroup indicates an expected call of SampleGroup
+func (mr *MockGroupsAPIMockRecorder) SampleGroup(id, k, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SampleGroup", reflect.TypeOf((*MockGroupsAPI)(nil).SampleGroup), id, k, opts)
+}
+
+// SampleGroupContext mocks base method
+func (m *MockGroupsAPI) SampleGroupContext(ctx context.Context, id, k int, opts gophish.SampleOptions) (*gophish.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SampleGroupContext", ctx, id, k, opts)
+	ret0, _ := ret[0].(*gophish.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SampleGroupContext indicates an expected call of SampleGroupContext
+func (mr *MockGroupsAPIMockRecorder) SampleGroupContext(ctx, id, k, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SampleGroupContext", reflect.TypeOf((*MockGroupsAPI)(nil).SampleGroupContext), ctx, id, k, opts)
+}
+
+// MockCampaignsAPI is a mock of CampaignsAPI interface
+type MockCampaignsAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCampaignsAPIMockRecorder
+}
+
+// MockCampaignsAPIMockRecorder is the mock recorder for MockCampaignsAPI
+type MockCampaignsAPIMockRecorder struct {
+	mock *MockCampaignsAPI
+}
+
+// NewMockCampaignsAPI creates a new mock instance
+func NewMockCampaignsAPI(ctrl *gomock.Controller) *MockCampaignsAPI {
+	mock := &MockCampaignsAPI{ctrl: ctrl}
+	mock.recorder = &MockCampaignsAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCampaignsAPI) EXPECT() *MockCampaignsAPIMockRecorder {
+	return m.recorder
+}
+
+// ListCampaigns mocks base method
+func (m *MockCampaignsAPI) ListCampaigns() ([]gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCampaigns")
+	ret0, _ := ret[0].([]gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCampaigns indicates an expected call of ListCampaigns
+func (mr *MockCampaignsAPIMockRecorder) ListCampaigns() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCampaigns", reflect.TypeOf((*MockCampaignsAPI)(nil).ListCampaigns))
+}
+
+// ListCampaignsContext mocks base method
+func (m *MockCampaignsAPI) ListCampaignsContext(ctx context.Context) ([]gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCampaignsContext", ctx)
+	ret0, _ := ret[0].([]gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCampaignsContext indicates an expected call of ListCampaignsContext
+func (mr *MockCampaignsAPIMockRecorder) ListCampaignsContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCampaignsContext", reflect.TypeOf((*MockCampaignsAPI)(nil).ListCampaignsContext), ctx)
+}
+
+// StreamCampaigns mocks base method
+func (m *MockCampaignsAPI) StreamCampaigns() (*gophish.CampaignIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamCampaigns")
+	ret0, _ := ret[0].(*gophish.CampaignIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamCampaigns indicates an expected call of StreamCampaigns
+func (mr *MockCampaignsAPIMockRecorder) StreamCampaigns() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamCampaigns", reflect.TypeOf((*MockCampaignsAPI)(nil).StreamCampaigns))
+}
+
+// StreamCampaignsContext mocks base method
+func (m *MockCampaignsAPI) StreamCampaignsContext(ctx context.Context) (*gophish.CampaignIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamCampaignsContext", ctx)
+	ret0, _ := ret[0].(*gophish.CampaignIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamCampaignsContext indicates an expected call of StreamCampaignsContext
+func (mr *MockCampaignsAPIMockRecorder) StreamCampaignsContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamCampaignsContext", reflect.TypeOf((*MockCampaignsAPI)(nil).StreamCampaignsContext), ctx)
+}
+
+// GetCampaign mocks base method
+func (m *MockCampaignsAPI) GetCampaign(id int) (*gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaign", id)
+	ret0, _ := ret[0].(*gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaign indicates an expected call of GetCampaign
+func (mr *MockCampaignsAPIMockRecorder) GetCampaign(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaign", reflect.TypeOf((*MockCampaignsAPI)(nil).GetCampaign), id)
+}
+
+// GetCampaignContext mocks base method
+func (m *MockCampaignsAPI) GetCampaignContext(ctx context.Context, id int) (*gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignContext", ctx, id)
+	ret0, _ := ret[0].(*gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignContext indicates an expected call of GetCampaignContext
+func (mr *MockCampaignsAPIMockRecorder) GetCampaignContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignContext", reflect.TypeOf((*MockCampaignsAPI)(nil).GetCampaignContext), ctx, id)
+}
+
+// GetCampaignResults mocks base method
+func (m *MockCampaignsAPI) GetCampaignResults(id int) (*gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignResults", id)
+	ret0, _ := ret[0].(*gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignResults indicates an expected call of GetCampaignResults
+func (mr *MockCampaignsAPIMockRecorder) GetCampaignResults(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignResults", reflect.TypeOf((*MockCampaignsAPI)(nil).GetCampaignResults), id)
+}
+
+// GetCampaignResultsContext mocks base method
+func (m *MockCampaignsAPI) GetCampaignResultsContext(ctx context.Context, id int) (*gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignResultsContext", ctx, id)
+	ret0, _ := ret[0].(*gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignResultsContext indicates an expected call of GetCampaignResultsContext
+func (mr *MockCampaignsAPIMockRecorder) GetCampaignResultsContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignResultsContext", reflect.TypeOf((*MockCampaignsAPI)(nil).GetCampaignResultsContext), ctx, id)
+}
+
+// GetCampaignSummary mocks base method
+func (m *MockCampaignsAPI) GetCampaignSummary(id int) (*gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignSummary", id)
+	ret0, _ := ret[0].(*gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignSummary indicates an expected call of GetCampaignSummary
+func (mr *MockCampaignsAPIMockRecorder) GetCampaignSummary(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignSummary", reflect.TypeOf((*MockCampaignsAPI)(nil).GetCampaignSummary), id)
+}
+
+// GetCampaignSummaryContext mocks base method
+func (m *MockCampaignsAPI) GetCampaignSummaryContext(ctx context.Context, id int) (*gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignSummaryContext", ctx, id)
+	ret0, _ := ret[0].(*gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignSummaryContext indicates an expected call of GetCampaignSummaryContext
+func (mr *MockCampaignsAPIMockRecorder) GetCampaignSummaryContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignSummaryContext", reflect.TypeOf((*MockCampaignsAPI)(nil).GetCampaignSummaryContext), ctx, id)
+}
+
+// CreateCampaign mocks base method
+func (m *MockCampaignsAPI) CreateCampaign(campaign *gophish.Campaign) (*gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCampaign", campaign)
+	ret0, _ := ret[0].(*gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCampaign indicates an expected call of CreateCampaign
+func (mr *MockCampaignsAPIMockRecorder) CreateCampaign(campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCampaign", reflect.TypeOf((*MockCampaignsAPI)(nil).CreateCampaign), campaign)
+}
+
+// CreateCampaignContext mocks base method
+func (m *MockCampaignsAPI) CreateCampaignContext(ctx context.Context, campaign *gophish.Campaign) (*gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCampaignContext", ctx, campaign)
+	ret0, _ := ret[0].(*gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCampaignContext indicates an expected call of CreateCampaignContext
+func (mr *MockCampaignsAPIMockRecorder) CreateCampaignContext(ctx, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCampaignContext", reflect.TypeOf((*MockCampaignsAPI)(nil).CreateCampaignContext), ctx, campaign)
+}
+
+// UpdateCampaign mocks base method
+func (m *MockCampaignsAPI) UpdateCampaign(campaign *gophish.Campaign) (*gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCampaign", campaign)
+	ret0, _ := ret[0].(*gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCampaign indicates an expected call of UpdateCampaign
+func (mr *MockCampaignsAPIMockRecorder) UpdateCampaign(campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCampaign", reflect.TypeOf((*MockCampaignsAPI)(nil).UpdateCampaign), campaign)
+}
+
+// UpdateCampaignContext mocks base method
+func (m *MockCampaignsAPI) UpdateCampaignContext(ctx context.Context, campaign *gophish.Campaign) (*gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCampaignContext", ctx, campaign)
+	ret0, _ := ret[0].(*gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCampaignContext indicates an expected call of UpdateCampaignContext
+func (mr *MockCampaignsAPIMockRecorder) UpdateCampaignContext(ctx, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCampaignContext", reflect.TypeOf((*MockCampaignsAPI)(nil).UpdateCampaignContext), ctx, campaign)
+}
+
+// DeleteCampaign mocks base method
+func (m *MockCampaignsAPI) DeleteCampaign(id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCampaign", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCampaign indicates an expected call of DeleteCampaign
+func (mr *MockCampaignsAPIMockRecorder) DeleteCampaign(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCampaign", reflect.TypeOf((*MockCampaignsAPI)(nil).DeleteCampaign), id)
+}
+
+// DeleteCampaignContext mocks base method
+func (m *MockCampaignsAPI) DeleteCampaignContext(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCampaignContext", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCampaignContext indicates an expected call of DeleteCampaignContext
+func (mr *MockCampaignsAPIMockRecorder) DeleteCampaignContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCampaignContext", reflect.TypeOf((*MockCampaignsAPI)(nil).DeleteCampaignContext), ctx, id)
+}
+
+// CompleteCampaign mocks base method
+func (m *MockCampaignsAPI) CompleteCampaign(id int) (*gophish.CompleteCampaignResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteCampaign", id)
+	ret0, _ := ret[0].(*gophish.CompleteCampaignResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteCampaign indicates an expected call of CompleteCampaign
+func (mr *MockCampaignsAPIMockRecorder) CompleteCampaign(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteCampaign", reflect.TypeOf((*MockCampaignsAPI)(nil).CompleteCampaign), id)
+}
+
+// CompleteCampaignContext mocks base method
+func (m *MockCampaignsAPI) CompleteCampaignContext(ctx context.Context, id int) (*gophish.CompleteCampaignResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteCampaignContext", ctx, id)
+	ret0, _ := ret[0].(*gophish.CompleteCampaignResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteCampaignContext indicates an expected call of CompleteCampaignContext
+func (mr *MockCampaignsAPIMockRecorder) CompleteCampaignContext(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteCampaignContext", reflect.TypeOf((*MockCampaignsAPI)(nil).CompleteCampaignContext), ctx, id)
+}
+
+// WaitForCampaignStatus mocks base method
+func (m *MockCampaignsAPI) WaitForCampaignStatus(ctx context.Context, id int, status gophish.CampaignStatus, interval time.Duration) (*gophish.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForCampaignStatus", ctx, id, status, interval)
+	ret0, _ := ret[0].(*gophish.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForCampaignStatus indicates an expected call of WaitForCampaignStatus
+func (mr *MockCampaignsAPIMockRecorder) WaitForCampaignStatus(ctx, id, status, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForCampaignStatus", reflect.TypeOf((*MockCampaignsAPI)(nil).WaitForCampaignStatus), ctx, id, status, interval)
+}
@@ -0,0 +1,315 @@
+package gophishtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ttacon/gophish"
+)
+
+// campaignSummary is the summary gophish returns for a campaign.
+type campaignSummary struct {
+	ID            int                    `json:"id"`
+	Name          string                 `json:"name"`
+	CreatedDate   gophish.Time           `json:"created_date"`
+	LaunchDate    gophish.Time           `json:"launch_date"`
+	SendByDate    gophish.Time           `json:"send_by_date"`
+	CompletedDate gophish.Time           `json:"completed_date"`
+	Status        gophish.CampaignStatus `json:"status"`
+	Stats         gophish.CampaignStats  `json:"stats"`
+}
+
+// campaignResults is what gophish returns for a campaign's results.
+type campaignResults struct {
+	ID       int                      `json:"id"`
+	Name     string                   `json:"name"`
+	Status   gophish.CampaignStatus   `json:"status"`
+	Results  []gophish.CampaignResult `json:"results"`
+	Timeline []gophish.CampaignEvent  `json:"timeline"`
+}
+
+func summarizeCampaign(c gophish.Campaign) campaignSummary {
+	return campaignSummary{
+		ID:            c.ID,
+		Name:          c.Name,
+		CreatedDate:   c.CreatedDate,
+		LaunchDate:    c.LaunchDate,
+		SendByDate:    c.SendByDate,
+		CompletedDate: c.CompletedDate,
+		Status:        c.Status,
+		Stats:         c.Stats,
+	}
+}
+
+func (s *Server) handleCampaigns(w http.ResponseWriter, r *http.Request) {
+	id, action, ok := route(w, r, "/api/campaigns/")
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id == 0 {
+		switch {
+		case action == "summary" && r.Method == http.MethodGet:
+			summaries := make([]campaignSummary, 0, len(s.campaigns))
+			for _, c := range s.listCampaigns() {
+				summaries = append(summaries, summarizeCampaign(c))
+			}
+			jsonResponse(w, http.StatusOK, struct {
+				Total     int64             `json:"total"`
+				Campaigns []campaignSummary `json:"campaigns"`
+			}{
+				Total:     int64(len(summaries)),
+				Campaigns: summaries,
+			})
+		case action != "":
+			jsonError(w, http.StatusNotFound, "Not found")
+		case r.Method == http.MethodGet:
+			jsonResponse(w, http.StatusOK, s.listCampaigns())
+		case r.Method == http.MethodPost:
+			var c gophish.Campaign
+			if !decode(w, r, &c) {
+				return
+			}
+			if err := s.launchCampaign(&c); err != nil {
+				jsonError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			jsonResponse(w, http.StatusCreated, c)
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	c, found := s.campaigns[id]
+	if !found {
+		jsonError(w, http.StatusNotFound, "Campaign not found")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && action == "":
+		jsonResponse(w, http.StatusOK, c)
+	case r.Method == http.MethodGet && action == "results":
+		jsonResponse(w, http.StatusOK, campaignResults{
+			ID:       c.ID,
+			Name:     c.Name,
+			Status:   c.Status,
+			Results:  c.Results,
+			Timeline: c.Timeline,
+		})
+	case r.Method == http.MethodGet && action == "summary":
+		jsonResponse(w, http.StatusOK, summarizeCampaign(c))
+	case r.Method == http.MethodGet && action == "complete":
+		c.Status = gophish.CampaignCompleted
+		c.CompletedDate = gophish.NewTime(s.now())
+		s.campaigns[id] = c
+		jsonSuccess(w, "Campaign completed successfully!")
+	case r.Method == http.MethodDelete && action == "":
+		delete(s.campaigns, id)
+		jsonSuccess(w, "Campaign deleted successfully!")
+	case action != "":
+		jsonError(w, http.StatusNotFound, "Not found")
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// listCampaigns returns every campaign ordered by ID, s.mu must be held.
+func (s *Server) listCampaigns() []gophish.Campaign {
+	campaigns := make([]gophish.Campaign, 0, len(s.campaigns))
+	for _, c := range s.campaigns {
+		campaigns = append(campaigns, c)
+	}
+	sort.Slice(campaigns, func(i, j int) bool {
+		return campaigns[i].ID < campaigns[j].ID
+	})
+	return campaigns
+}
+
+// launchCampaign validates a new campaign and resolves the resources it
+// references by name, the same way gophish does. Campaigns launching in the
+// future are queued, others are immediately "sent" to every target. s.mu
+// must be held.
+func (s *Server) launchCampaign(c *gophish.Campaign) error {
+	switch {
+	case c.Name == "":
+		return errors.New("Campaign name not specified")
+	case len(c.Groups) == 0:
+		return errors.New("No groups specified")
+	case c.Template.Name == "":
+		return errors.New("No email template specified")
+	case c.Page.Name == "":
+		return errors.New("No landing page specified")
+	case c.SMTP.Name == "":
+		return errors.New("No sending profile specified")
+	}
+
+	for i, ref := range c.Groups {
+		g := s.groupByName(ref.Name)
+		if g == nil {
+			return errors.New("Group not found")
+		}
+		c.Groups[i] = *g
+	}
+	t := s.templateByName(c.Template.Name)
+	if t == nil {
+		return errors.New("Template not found")
+	}
+	p := s.pageByName(c.Page.Name)
+	if p == nil {
+		return errors.New("Page not found")
+	}
+	sp := s.sendingProfileByName(c.SMTP.Name)
+	if sp == nil {
+		return errors.New("Sending profile not found")
+	}
+	c.Template, c.Page, c.SMTP = *t, *p, *sp
+
+	now := s.now()
+	c.ID = s.nextID()
+	c.CreatedDate = gophish.NewTime(now)
+	if !c.LaunchDate.IsSet() {
+		c.LaunchDate = gophish.NewTime(now)
+	}
+	c.CompletedDate = gophish.Time{}
+	c.Status = gophish.CampaignQueued
+	launched := !c.LaunchDate.After(now)
+	if launched {
+		c.Status = gophish.CampaignInProgress
+	}
+
+	c.Results = nil
+	c.Timeline = []gophish.CampaignEvent{{
+		Time:    gophish.NewTime(now),
+		Message: gophish.EventCampaignCreated,
+	}}
+	seen := make(map[string]bool)
+	for _, g := range c.Groups {
+		for _, t := range g.Targets {
+			key := strings.ToLower(t.Email)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			result := gophish.CampaignResult{
+				ID:        newResultID(),
+				Email:     t.Email,
+				FirstName: t.FirstName,
+				LastName:  t.LastName,
+				Position:  t.Position,
+				Status:    gophish.ResultScheduled,
+			}
+			if launched {
+				result.Status = gophish.ResultEmailSent
+				result.SendDate = gophish.NewTime(now)
+				c.Timeline = append(c.Timeline, gophish.CampaignEvent{
+					Email:   t.Email,
+					Time:    gophish.NewTime(now),
+					Message: gophish.EventEmailSent,
+				})
+			}
+			c.Results = append(c.Results, result)
+		}
+	}
+	c.Stats = campaignStats(c.Results)
+
+	s.campaigns[c.ID] = *c
+	return nil
+}
+
+// RecordEvent simulates a recipient interacting with a campaign, i.e. opening
+// the email (gophish.EventEmailOpened) or clicking its link
+// (gophish.EventClickedLink). The event is added to the campaign's timeline
+// and the recipient's result and the campaign's stats are updated to match.
+func (s *Server) RecordEvent(campaignID int, email string, msg gophish.EventMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, found := s.campaigns[campaignID]
+	if !found {
+		return fmt.Errorf("gophishtest: no campaign with ID %d", campaignID)
+	}
+
+	var result *gophish.CampaignResult
+	for i := range c.Results {
+		if strings.EqualFold(c.Results[i].Email, email) {
+			result = &c.Results[i]
+			break
+		}
+	}
+	if result == nil {
+		return fmt.Errorf(
+			"gophishtest: %s isn't a recipient of campaign %d",
+			email,
+			campaignID,
+		)
+	}
+
+	now := gophish.NewTime(s.now())
+	switch msg {
+	case gophish.EventEmailReported:
+		result.Reported = true
+	case gophish.EventEmailSent:
+		result.SendDate = now
+		fallthrough
+	default:
+		status := gophish.ResultStatus(msg)
+		if result.Status.Rank() <= status.Rank() {
+			result.Status = status
+		}
+	}
+
+	c.Timeline = append(c.Timeline, gophish.CampaignEvent{
+		Email:   result.Email,
+		Time:    now,
+		Message: msg,
+	})
+	c.Stats = campaignStats(c.Results)
+	s.campaigns[campaignID] = c
+	return nil
+}
+
+// campaignStats tallies the results of a campaign the same way gophish does,
+// where a recipient who clicked a link also counts as having opened the
+// email.
+func campaignStats(results []gophish.CampaignResult) gophish.CampaignStats {
+	stats := gophish.CampaignStats{Total: len(results)}
+	for _, r := range results {
+		if r.HasBeenSent() {
+			stats.Sent++
+		}
+		if r.HasOpened() {
+			stats.Opened++
+		}
+		if r.HasClicked() {
+			stats.Clicked++
+		}
+		if r.HasSubmitted() {
+			stats.SubmittedData++
+		}
+		if r.Reported {
+			stats.EmailReported++
+		}
+	}
+	return stats
+}
+
+// newResultID generates the random ID gophish gives each recipient of a
+// campaign, used in tracking links.
+func newResultID() string {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)[:7]
+}
@@ -0,0 +1,337 @@
+package gophishtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// cassetteVersion is the version of the cassette format written by
+// Recorder.
+const cassetteVersion = 1
+
+// redacted replaces secrets in recorded interactions.
+const redacted = "REDACTED"
+
+// Cassette is a set of recorded interactions with a gophish server.
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and the response to it.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request as recorded in a cassette. Only the path and
+// query of the URL are kept, so that a cassette can be replayed regardless
+// of the host it was recorded against.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body"`
+}
+
+// RecordedResponse is a response as recorded in a cassette.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// Recorder is an http.RoundTripper that records every request made through
+// it, along with the response, so they can be saved to a cassette file and
+// replayed later with a Replayer. API tokens and sending profile passwords
+// are scrubbed before they're recorded.
+//
+//	rec := gophishtest.NewRecorder("testdata/campaigns.json", nil)
+//	client := gophish.NewClient(host, token, gophish.WithHTTPClient(
+//		&http.Client{Transport: rec},
+//	))
+//	// ...
+//	err := rec.Save()
+type Recorder struct {
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder creates a Recorder that saves to the cassette at path, sending
+// requests through transport (http.DefaultTransport if nil).
+func NewRecorder(path string, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{
+		path:      path,
+		transport: transport,
+		cassette:  Cassette{Version: cassetteVersion},
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    scrubURL(req.URL),
+			Header: scrubHeader(req.Header),
+			Body:   scrubBody(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+			Body:       scrubBody(respBody),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Save writes every interaction recorded so far to the cassette file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0600)
+}
+
+// Replayer is an http.RoundTripper that serves the responses recorded in a
+// cassette. Each request is matched against the first unused interaction
+// with the same method, path, query and body. Requests that don't match any
+// interaction fail.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer loads the cassette at path for replay.
+func NewReplayer(path string) (*Replayer, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("gophishtest: invalid cassette %s: %v", path, err)
+	}
+	if cassette.Version != cassetteVersion {
+		return nil, fmt.Errorf(
+			"gophishtest: unsupported cassette version %d in %s",
+			cassette.Version,
+			path,
+		)
+	}
+
+	return &Replayer{
+		interactions: cassette.Interactions,
+		used:         make([]bool, len(cassette.Interactions)),
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	reqURL := scrubURL(req.URL)
+	body = normalizeBody(req.Header, scrubBody(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		recorded := interaction.Request
+		if r.used[i] ||
+			recorded.Method != req.Method ||
+			recorded.URL != reqURL ||
+			!sameBody(normalizeBody(recorded.Header, recorded.Body), body) {
+			continue
+		}
+		r.used[i] = true
+
+		recordedResp := interaction.Response
+		return &http.Response{
+			Status: fmt.Sprintf(
+				"%d %s",
+				recordedResp.StatusCode,
+				http.StatusText(recordedResp.StatusCode),
+			),
+			StatusCode:    recordedResp.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recordedResp.Header.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(recordedResp.Body)),
+			ContentLength: int64(len(recordedResp.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf(
+		"gophishtest: unexpected request %s %s",
+		req.Method,
+		reqURL,
+	)
+}
+
+// Unused returns the recorded interactions that haven't been replayed, which
+// usually means the code under test made fewer requests than when the
+// cassette was recorded.
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// readBody reads a request or response body, replacing it with an in-memory
+// copy so that it can still be read by its consumer.
+func readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+	data, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return "", err
+	}
+	*body = ioutil.NopCloser(bytes.NewReader(data))
+	return string(data), nil
+}
+
+func scrubURL(u *url.URL) string {
+	scrubbed := url.URL{Path: u.Path}
+	query := u.Query()
+	if query.Get("api_key") != "" {
+		query.Set("api_key", redacted)
+	}
+	scrubbed.RawQuery = query.Encode()
+	return scrubbed.String()
+}
+
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	for _, key := range []string{"Authorization", "Cookie", "Set-Cookie"} {
+		if scrubbed.Get(key) != "" {
+			scrubbed.Set(key, redacted)
+		}
+	}
+	return scrubbed
+}
+
+// scrubBody redacts the password of every sending profile in a JSON body,
+// including those embedded in campaigns. Bodies that aren't JSON are
+// returned as is.
+func scrubBody(body string) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return body
+	}
+	if !scrubPasswords(v) {
+		return body
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+// scrubPasswords redacts every non-empty "password" field in a decoded JSON
+// value, reporting whether anything was redacted.
+func scrubPasswords(v interface{}) bool {
+	scrubbed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if key == "password" {
+				if s, ok := value.(string); ok && s != "" {
+					v[key] = redacted
+					scrubbed = true
+				}
+				continue
+			}
+			if scrubPasswords(value) {
+				scrubbed = true
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if scrubPasswords(value) {
+				scrubbed = true
+			}
+		}
+	}
+	return scrubbed
+}
+
+// multipartBoundary replaces the random boundary of multipart bodies, so
+// that the same upload can be matched across runs.
+const multipartBoundary = "gophishtest-boundary"
+
+// normalizeBody replaces the boundary of a multipart body with
+// multipartBoundary, other bodies are returned as is.
+func normalizeBody(header http.Header, body string) string {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" {
+		return body
+	}
+	return strings.Replace(body, params["boundary"], multipartBoundary, -1)
+}
+
+// sameBody compares two request bodies, semantically for JSON bodies and
+// byte for byte otherwise.
+func sameBody(recorded, body string) bool {
+	var a, b interface{}
+	errA := json.Unmarshal([]byte(recorded), &a)
+	errB := json.Unmarshal([]byte(body), &b)
+	if errA == nil && errB == nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return recorded == body
+}
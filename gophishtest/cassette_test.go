@@ -0,0 +1,172 @@
+package gophishtest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ttacon/gophish"
+)
+
+const (
+	secretToken    = "s3cret-token"
+	secretPassword = "hunter2"
+)
+
+// session makes the requests that are recorded and replayed.
+func session(client *gophish.Client) error {
+	if _, err := client.SendingProfiles.CreateSendingProfile(&gophish.SendingProfile{
+		Name:        "Corp SMTP",
+		Host:        "smtp.example.com:587",
+		FromAddress: "it@example.com",
+		Username:    "it",
+		Password:    secretPassword,
+	}); err != nil {
+		return err
+	}
+	if _, err := client.SendingProfiles.ListSendingProfiles(); err != nil {
+		return err
+	}
+	_, err := client.Groups.ImportTargets(
+		strings.NewReader("Email\njdoe@example.com\n"),
+		"targets.csv",
+	)
+	return err
+}
+
+func record(t *testing.T, path string) {
+	t.Helper()
+
+	srv := NewServer(secretToken)
+	defer srv.Close()
+
+	rec := NewRecorder(path, nil)
+	client := srv.Client(gophish.WithHTTPClient(&http.Client{Transport: rec}))
+	if err := session(client); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func replayClient(t *testing.T, path string) (*Replayer, *gophish.Client) {
+	t.Helper()
+
+	rep, err := NewReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rep, gophish.NewClient(
+		"https://gophish.invalid",
+		"another-token",
+		gophish.WithHTTPClient(&http.Client{Transport: rep}),
+	)
+}
+
+func TestCassetteScrubsSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gophishtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.json")
+	record(t, path)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{secretToken, secretPassword} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), redacted) {
+		t.Errorf("cassette has nothing redacted:\n%s", data)
+	}
+}
+
+func TestCassetteReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gophishtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.json")
+	record(t, path)
+
+	rep, client := replayClient(t, path)
+	if err := session(client); err != nil {
+		t.Fatalf("replaying the recorded session: %v", err)
+	}
+	if unused := rep.Unused(); len(unused) != 0 {
+		t.Errorf("got %d unused interactions after a full replay", len(unused))
+	}
+
+	// Every interaction is only replayed once.
+	if _, err := client.SendingProfiles.ListSendingProfiles(); err == nil ||
+		!strings.Contains(err.Error(), "unexpected request GET /api/smtp") {
+		t.Errorf("got error %v for an extra request, want an unexpected request", err)
+	}
+}
+
+func TestCassetteReplayMismatches(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gophishtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.json")
+	record(t, path)
+
+	rep, client := replayClient(t, path)
+
+	if _, err := client.Templates.ListTemplates(); err == nil {
+		t.Error("replayed a request that wasn't recorded")
+	}
+	if _, err := client.SendingProfiles.CreateSendingProfile(&gophish.SendingProfile{
+		Name:        "Other SMTP",
+		Host:        "smtp.example.com:587",
+		FromAddress: "it@example.com",
+	}); err == nil {
+		t.Error("replayed a request with a different JSON body")
+	}
+	if _, err := client.Groups.ImportTargets(
+		strings.NewReader("Email\nasmith@example.com\n"),
+		"targets.csv",
+	); err == nil {
+		t.Error("replayed an upload of a different file")
+	}
+
+	if unused := rep.Unused(); len(unused) != 3 {
+		t.Errorf("got %d unused interactions, want 3", len(unused))
+	}
+}
+
+func TestNewReplayerInvalidCassette(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gophishtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for name, content := range map[string]string{
+		"garbage.json": "not json",
+		"version.json": `{"version": 99, "interactions": []}`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewReplayer(path); err == nil {
+			t.Errorf("%s: got no error", name)
+		}
+	}
+	if _, err := NewReplayer(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("missing cassette: got no error")
+	}
+}
@@ -0,0 +1,263 @@
+package gophishtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/ttacon/gophish"
+)
+
+// handleImportGroup parses the targets in an uploaded CSV file, without
+// creating a group.
+func (s *Server) handleImportGroup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	targets, err := parseTargetsCSV(r)
+	if err != nil {
+		// gophish reports any failure to parse the upload as a server error.
+		jsonError(w, http.StatusInternalServerError, "Error parsing CSV")
+		return
+	}
+	jsonResponse(w, http.StatusOK, targets)
+}
+
+// parseTargetsCSV parses every file in a multipart upload the same way
+// gophish does: columns are matched by their (case insensitive) header, and
+// a row with an invalid email address fails the whole upload.
+func parseTargetsCSV(r *http.Request) ([]gophish.Target, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []gophish.Target{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return targets, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FileName() == "" {
+			continue
+		}
+		parsed, err := parseTargetsPart(part)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, parsed...)
+	}
+}
+
+func parseTargetsPart(part *multipart.Part) ([]gophish.Target, error) {
+	rows, err := csv.NewReader(part).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{
+		"first name": -1,
+		"last name":  -1,
+		"email":      -1,
+		"position":   -1,
+	}
+	for i, header := range rows[0] {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if _, ok := columns[header]; ok {
+			columns[header] = i
+		}
+	}
+	field := func(row []string, column string) string {
+		i := columns[column]
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var targets []gophish.Target
+	for _, row := range rows[1:] {
+		email := field(row, "email")
+		if email != "" {
+			addr, err := mail.ParseAddress(email)
+			if err != nil {
+				return nil, err
+			}
+			email = addr.Address
+		}
+		targets = append(targets, gophish.Target{
+			FirstName: field(row, "first name"),
+			LastName:  field(row, "last name"),
+			Email:     email,
+			Position:  field(row, "position"),
+		})
+	}
+	return targets, nil
+}
+
+var hrefPattern = regexp.MustCompile(`(?i)(href\s*=\s*["'])[^"']*(["'])`)
+
+// handleImportEmail converts a raw email into a template, without saving it.
+func (s *Server) handleImportEmail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	var imp gophish.ImportRequest
+	if !decode(w, r, &imp) {
+		return
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(imp.Content))
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	text, html, err := emailBodies(
+		msg.Header.Get("Content-Type"),
+		msg.Header.Get("Content-Transfer-Encoding"),
+		msg.Body,
+	)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if imp.ConvertLinks {
+		html = hrefPattern.ReplaceAllString(html, "${1}{{.URL}}${2}")
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+	jsonResponse(w, http.StatusOK, gophish.Template{
+		Subject: subject,
+		Text:    text,
+		HTML:    html,
+	})
+}
+
+// emailBodies extracts the plaintext and HTML bodies of an email, descending
+// into multipart bodies.
+func emailBodies(contentType, encoding string, body io.Reader) (text, html string, err error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		// Emails without a Content-Type are plaintext.
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return text, html, nil
+			}
+			if err != nil {
+				return "", "", err
+			}
+			t, h, err := emailBodies(
+				part.Header.Get("Content-Type"),
+				part.Header.Get("Content-Transfer-Encoding"),
+				part,
+			)
+			if err != nil {
+				return "", "", err
+			}
+			if text == "" {
+				text = t
+			}
+			if html == "" {
+				html = h
+			}
+		}
+	}
+
+	switch strings.ToLower(encoding) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch mediaType {
+	case "text/plain":
+		return string(data), "", nil
+	case "text/html":
+		return "", string(data), nil
+	}
+	return "", "", nil
+}
+
+var headPattern = regexp.MustCompile(`(?i)<head[^>]*>`)
+
+// handleImportSite fetches a page to be used as a landing page, without
+// saving it.
+func (s *Server) handleImportSite(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	var imp gophish.ImportSiteRequest
+	if !decode(w, r, &imp) {
+		return
+	}
+
+	html, err := fetchSite(imp.URL)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !imp.IncludeResources {
+		base := `<base href="` + imp.URL + `">`
+		if loc := headPattern.FindStringIndex(html); loc != nil {
+			html = html[:loc[1]] + base + html[loc[1]:]
+		} else {
+			html = base + html
+		}
+	}
+	jsonResponse(w, http.StatusOK, struct {
+		HTML string `json:"html"`
+	}{html})
+}
+
+func fetchSite(url string) (string, error) {
+	if url == "" {
+		return "", errors.New("No URL specified")
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,462 @@
+package gophishtest
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"github.com/ttacon/gophish"
+)
+
+func (s *Server) handleSendingProfiles(w http.ResponseWriter, r *http.Request) {
+	id, action, ok := route(w, r, "/api/smtp/")
+	if !ok {
+		return
+	}
+	if action != "" {
+		jsonError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			profiles := make([]gophish.SendingProfile, 0, len(s.smtp))
+			for _, sp := range s.smtp {
+				profiles = append(profiles, sp)
+			}
+			sort.Slice(profiles, func(i, j int) bool {
+				return profiles[i].ID < profiles[j].ID
+			})
+			jsonResponse(w, http.StatusOK, profiles)
+		case http.MethodPost:
+			var sp gophish.SendingProfile
+			if !decode(w, r, &sp) {
+				return
+			}
+			if s.sendingProfileByName(sp.Name) != nil {
+				jsonError(w, http.StatusConflict, "SMTP name already in use")
+				return
+			}
+			if err := validateSendingProfile(&sp); err != nil {
+				jsonError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			sp.ID = s.nextID()
+			sp.ModifiedDate = gophish.NewTime(s.now())
+			s.smtp[sp.ID] = sp
+			jsonResponse(w, http.StatusCreated, sp)
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	existing, found := s.smtp[id]
+	if !found {
+		jsonError(w, http.StatusNotFound, "SMTP not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		jsonResponse(w, http.StatusOK, existing)
+	case http.MethodPut:
+		var sp gophish.SendingProfile
+		if !decode(w, r, &sp) {
+			return
+		}
+		if sp.ID != id {
+			jsonError(w, http.StatusBadRequest, "/:id and /:smtp_id mismatch")
+			return
+		}
+		if err := validateSendingProfile(&sp); err != nil {
+			jsonError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sp.ModifiedDate = gophish.NewTime(s.now())
+		s.smtp[id] = sp
+		jsonResponse(w, http.StatusOK, sp)
+	case http.MethodDelete:
+		delete(s.smtp, id)
+		jsonSuccess(w, "SMTP Deleted Successfully")
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func validateSendingProfile(sp *gophish.SendingProfile) error {
+	switch {
+	case sp.FromAddress == "":
+		return fmt.Errorf("No From Address specified")
+	case sp.Host == "":
+		return fmt.Errorf("No SMTP Host specified")
+	}
+	if _, err := mail.ParseAddress(sp.FromAddress); err != nil {
+		return fmt.Errorf("Invalid SMTP From address because it is not an RFC 5322 compliant email address")
+	}
+	if _, _, err := net.SplitHostPort(sp.Host); err != nil {
+		// gophish defaults to port 25 if none is given.
+		sp.Host += ":25"
+	}
+	if sp.InterfaceType == "" {
+		sp.InterfaceType = "SMTP"
+	}
+	return nil
+}
+
+// sendingProfileByName looks up a sending profile, s.mu must be held.
+func (s *Server) sendingProfileByName(name string) *gophish.SendingProfile {
+	for _, sp := range s.smtp {
+		if sp.Name == name {
+			return &sp
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleTemplates(w http.ResponseWriter, r *http.Request) {
+	id, action, ok := route(w, r, "/api/templates/")
+	if !ok {
+		return
+	}
+	if action != "" {
+		jsonError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			templates := make([]gophish.Template, 0, len(s.templates))
+			for _, t := range s.templates {
+				templates = append(templates, t)
+			}
+			sort.Slice(templates, func(i, j int) bool {
+				return templates[i].ID < templates[j].ID
+			})
+			jsonResponse(w, http.StatusOK, templates)
+		case http.MethodPost:
+			var t gophish.Template
+			if !decode(w, r, &t) {
+				return
+			}
+			if s.templateByName(t.Name) != nil {
+				jsonError(w, http.StatusConflict, "Template name already in use")
+				return
+			}
+			if err := validateTemplate(&t); err != nil {
+				jsonError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			t.ID = s.nextID()
+			t.ModifiedDate = gophish.NewTime(s.now())
+			s.templates[t.ID] = t
+			jsonResponse(w, http.StatusCreated, t)
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	existing, found := s.templates[id]
+	if !found {
+		jsonError(w, http.StatusNotFound, "Template not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		jsonResponse(w, http.StatusOK, existing)
+	case http.MethodPut:
+		var t gophish.Template
+		if !decode(w, r, &t) {
+			return
+		}
+		if t.ID != id {
+			jsonError(w, http.StatusBadRequest, "Error: /:id and template_id mismatch")
+			return
+		}
+		if err := validateTemplate(&t); err != nil {
+			jsonError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		t.ModifiedDate = gophish.NewTime(s.now())
+		s.templates[id] = t
+		jsonResponse(w, http.StatusOK, t)
+	case http.MethodDelete:
+		delete(s.templates, id)
+		jsonSuccess(w, "Template deleted successfully!")
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func validateTemplate(t *gophish.Template) error {
+	switch {
+	case t.Name == "":
+		return fmt.Errorf("Template name not specified")
+	case t.Text == "" && t.HTML == "":
+		return fmt.Errorf("Need to specify at least plaintext or HTML content")
+	}
+	return nil
+}
+
+// templateByName looks up a template, s.mu must be held.
+func (s *Server) templateByName(name string) *gophish.Template {
+	for _, t := range s.templates {
+		if t.Name == name {
+			return &t
+		}
+	}
+	return nil
+}
+
+func (s *Server) handlePages(w http.ResponseWriter, r *http.Request) {
+	id, action, ok := route(w, r, "/api/pages/")
+	if !ok {
+		return
+	}
+	if action != "" {
+		jsonError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			pages := make([]gophish.LandingPage, 0, len(s.pages))
+			for _, p := range s.pages {
+				pages = append(pages, p)
+			}
+			sort.Slice(pages, func(i, j int) bool {
+				return pages[i].ID < pages[j].ID
+			})
+			jsonResponse(w, http.StatusOK, pages)
+		case http.MethodPost:
+			var p gophish.LandingPage
+			if !decode(w, r, &p) {
+				return
+			}
+			if s.pageByName(p.Name) != nil {
+				jsonError(w, http.StatusConflict, "Page name already in use")
+				return
+			}
+			if err := validatePage(&p); err != nil {
+				jsonError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			p.ID = s.nextID()
+			p.ModifiedDate = gophish.NewTime(s.now())
+			s.pages[p.ID] = p
+			jsonResponse(w, http.StatusCreated, p)
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	existing, found := s.pages[id]
+	if !found {
+		jsonError(w, http.StatusNotFound, "Page not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		jsonResponse(w, http.StatusOK, existing)
+	case http.MethodPut:
+		var p gophish.LandingPage
+		if !decode(w, r, &p) {
+			return
+		}
+		if p.ID != id {
+			jsonError(w, http.StatusBadRequest, "/:id and /:page_id mismatch")
+			return
+		}
+		if err := validatePage(&p); err != nil {
+			jsonError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		p.ModifiedDate = gophish.NewTime(s.now())
+		s.pages[id] = p
+		jsonResponse(w, http.StatusOK, p)
+	case http.MethodDelete:
+		delete(s.pages, id)
+		jsonSuccess(w, "Page Deleted Successfully")
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func validatePage(p *gophish.LandingPage) error {
+	if p.Name == "" {
+		return fmt.Errorf("Page Name not specified")
+	}
+	// gophish never captures passwords without capturing credentials.
+	if !p.CaptureCredentials {
+		p.CapturePasswords = false
+	}
+	return nil
+}
+
+// pageByName looks up a landing page, s.mu must be held.
+func (s *Server) pageByName(name string) *gophish.LandingPage {
+	for _, p := range s.pages {
+		if p.Name == name {
+			return &p
+		}
+	}
+	return nil
+}
+
+func summarizeGroup(g gophish.Group) gophish.GroupSummary {
+	return gophish.GroupSummary{
+		ID:           g.ID,
+		Name:         g.Name,
+		ModifiedDate: g.ModifiedDate,
+		NumTargets:   int64(len(g.Targets)),
+	}
+}
+
+func (s *Server) handleGroups(w http.ResponseWriter, r *http.Request) {
+	id, action, ok := route(w, r, "/api/groups/")
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id == 0 {
+		switch {
+		case action == "summary" && r.Method == http.MethodGet:
+			summaries := gophish.GroupSummaries{Groups: []gophish.GroupSummary{}}
+			for _, g := range s.listGroups() {
+				summaries.Groups = append(summaries.Groups, summarizeGroup(g))
+			}
+			summaries.Total = int64(len(summaries.Groups))
+			jsonResponse(w, http.StatusOK, summaries)
+		case action != "":
+			jsonError(w, http.StatusNotFound, "Not found")
+		case r.Method == http.MethodGet:
+			jsonResponse(w, http.StatusOK, s.listGroups())
+		case r.Method == http.MethodPost:
+			var g gophish.Group
+			if !decode(w, r, &g) {
+				return
+			}
+			if s.groupByName(g.Name) != nil {
+				jsonError(w, http.StatusConflict, "Group name already in use")
+				return
+			}
+			if err := validateGroup(&g); err != nil {
+				jsonError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			g.ID = s.nextID()
+			g.ModifiedDate = gophish.NewTime(s.now())
+			s.groups[g.ID] = g
+			jsonResponse(w, http.StatusCreated, g)
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	existing, found := s.groups[id]
+	if !found {
+		jsonError(w, http.StatusNotFound, "Group not found")
+		return
+	}
+
+	switch {
+	case action == "summary" && r.Method == http.MethodGet:
+		jsonResponse(w, http.StatusOK, summarizeGroup(existing))
+	case action != "":
+		jsonError(w, http.StatusNotFound, "Not found")
+	case r.Method == http.MethodGet:
+		jsonResponse(w, http.StatusOK, existing)
+	case r.Method == http.MethodPut:
+		var g gophish.Group
+		if !decode(w, r, &g) {
+			return
+		}
+		if g.ID != id {
+			jsonError(w, http.StatusBadRequest, "Error: /:id and group_id mismatch")
+			return
+		}
+		if err := validateGroup(&g); err != nil {
+			jsonError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		g.ModifiedDate = gophish.NewTime(s.now())
+		s.groups[id] = g
+		jsonResponse(w, http.StatusOK, g)
+	case r.Method == http.MethodDelete:
+		delete(s.groups, id)
+		jsonSuccess(w, "Group deleted successfully!")
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func validateGroup(g *gophish.Group) error {
+	switch {
+	case g.Name == "":
+		return fmt.Errorf("Group name not specified")
+	case len(g.Targets) == 0:
+		return fmt.Errorf("No targets specified")
+	}
+
+	// gophish drops targets with duplicate email addresses.
+	seen := make(map[string]bool, len(g.Targets))
+	targets := make([]gophish.Target, 0, len(g.Targets))
+	for _, t := range g.Targets {
+		if _, err := mail.ParseAddress(t.Email); err != nil {
+			return err
+		}
+		key := strings.ToLower(t.Email)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		targets = append(targets, t)
+	}
+	g.Targets = targets
+	return nil
+}
+
+// listGroups returns every group ordered by ID, s.mu must be held.
+func (s *Server) listGroups() []gophish.Group {
+	groups := make([]gophish.Group, 0, len(s.groups))
+	for _, g := range s.groups {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].ID < groups[j].ID
+	})
+	return groups
+}
+
+// groupByName looks up a group, s.mu must be held.
+func (s *Server) groupByName(name string) *gophish.Group {
+	for _, g := range s.groups {
+		if g.Name == name {
+			return &g
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+// Package gophishtest provides an in-process fake of the gophish API for
+// testing code that uses the gophish client without a real gophish
+// installation.
+//
+// The fake keeps every resource in memory, checks the API token on every
+// request and rejects invalid payloads with the same status codes and
+// messages as gophish itself:
+//
+//	srv := gophishtest.NewServer("token")
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	group, err := client.Groups.CreateGroup(&gophish.Group{...})
+package gophishtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ttacon/gophish"
+)
+
+// Server is a fake gophish API server. It's safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// Token is the API token that requests have to be authenticated with.
+	Token string
+
+	mu        sync.Mutex
+	lastID    int
+	smtp      map[int]gophish.SendingProfile
+	templates map[int]gophish.Template
+	pages     map[int]gophish.LandingPage
+	groups    map[int]gophish.Group
+	campaigns map[int]gophish.Campaign
+
+	// now returns the current time, see WithClock.
+	now func() time.Time
+}
+
+// ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// WithClock makes the server take the timestamps it sets (i.e. ModifiedDate,
+// or a campaign's CreatedDate and LaunchDate) from now rather than the
+// current time, so that tests can get stable timestamps.
+func WithClock(now func() time.Time) ServerOption {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// NewServer starts a fake gophish server that accepts the given API token.
+// The server should be closed once done.
+func NewServer(token string, opts ...ServerOption) *Server {
+	s := &Server{
+		Token:     token,
+		smtp:      make(map[int]gophish.SendingProfile),
+		templates: make(map[int]gophish.Template),
+		pages:     make(map[int]gophish.LandingPage),
+		groups:    make(map[int]gophish.Group),
+		campaigns: make(map[int]gophish.Campaign),
+		now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(s.authenticate(s.routes()))
+	return s
+}
+
+// Client returns a gophish client for the server, authenticated with the
+// server's token.
+func (s *Server) Client(opts ...gophish.Option) *gophish.Client {
+	return gophish.NewClient(s.URL, s.Token, opts...)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/smtp/", s.handleSendingProfiles)
+	mux.HandleFunc("/api/templates/", s.handleTemplates)
+	mux.HandleFunc("/api/pages/", s.handlePages)
+	mux.HandleFunc("/api/groups/", s.handleGroups)
+	mux.HandleFunc("/api/campaigns/", s.handleCampaigns)
+	mux.HandleFunc("/api/import/group", s.handleImportGroup)
+	mux.HandleFunc("/api/import/email", s.handleImportEmail)
+	mux.HandleFunc("/api/import/site", s.handleImportSite)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		jsonError(w, http.StatusNotFound, "Not found")
+	})
+
+	// gophish serves every collection both with and without a trailing
+	// slash.
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Count(r.URL.Path, "/") == 2 {
+			r.URL.Path += "/"
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// authenticate checks the API key the same way gophish does, accepting it
+// either as the api_key query parameter or the Authorization header (with
+// or without a "Bearer " prefix).
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("api_key")
+		if key == "" {
+			key = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		}
+		switch {
+		case key == "":
+			jsonError(w, http.StatusUnauthorized, "API Key not set")
+		case key != s.Token:
+			jsonError(w, http.StatusUnauthorized, "Invalid API Key")
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// response is gophish's generic response for actions that don't return a
+// resource, and for errors.
+type response struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+}
+
+func jsonResponse(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func jsonError(w http.ResponseWriter, status int, msg string) {
+	jsonResponse(w, status, response{Success: false, Message: msg})
+}
+
+func jsonSuccess(w http.ResponseWriter, msg string) {
+	jsonResponse(w, http.StatusOK, response{Success: true, Message: msg})
+}
+
+func methodNotAllowed(w http.ResponseWriter) {
+	jsonError(w, http.StatusMethodNotAllowed, "Method not allowed")
+}
+
+// route splits the path below the given collection prefix into the
+// resource ID (0 if none) and any remaining action, i.e.
+// "/api/campaigns/3/results" gives (3, "results").
+func route(w http.ResponseWriter, r *http.Request, prefix string) (id int, action string, ok bool) {
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if rest == "" {
+		return 0, "", true
+	}
+
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) == 2 {
+		action = parts[1]
+	}
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		// Collection level actions, i.e. /api/groups/summary.
+		if len(parts) == 1 {
+			return 0, parts[0], true
+		}
+		jsonError(w, http.StatusNotFound, "Not found")
+		return 0, "", false
+	}
+	return id, action, true
+}
+
+// decode reads a JSON payload, responding with a 400 if it's malformed.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		jsonError(w, http.StatusBadRequest, "Invalid JSON structure")
+		return false
+	}
+	return true
+}
+
+// nextID returns the next free resource ID, s.mu must be held.
+func (s *Server) nextID() int {
+	s.lastID++
+	return s.lastID
+}
@@ -0,0 +1,72 @@
+package gophishtest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ttacon/gophish"
+)
+
+func TestWithClock(t *testing.T) {
+	now := time.Date(2020, 5, 8, 15, 7, 1, 0, time.UTC)
+	srv := NewServer("token", WithClock(func() time.Time { return now }))
+	defer srv.Close()
+
+	tmpl, err := srv.Client().Templates.CreateTemplate(&gophish.Template{
+		Name: "Template",
+		Text: "text",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tmpl.ModifiedDate.Equal(now) {
+		t.Errorf("got modified date %v, want %v", tmpl.ModifiedDate, now)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	srv := NewServer("token")
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		status int
+	}{
+		{"header", "/api/templates/", "token", http.StatusOK},
+		{"bearer header", "/api/templates/", "Bearer token", http.StatusOK},
+		{"query", "/api/templates/?api_key=token", "", http.StatusOK},
+		{"no trailing slash", "/api/templates", "token", http.StatusOK},
+		{"missing", "/api/templates/", "", http.StatusUnauthorized},
+		{"wrong", "/api/templates/", "nope", http.StatusUnauthorized},
+		{"unknown path", "/api/nope/", "token", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+tt.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.header != "" {
+			req.Header.Set("Authorization", tt.header)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s: got status %d, want %d", tt.name, resp.StatusCode, tt.status)
+		}
+	}
+}
+
+func TestRecordEvent(t *testing.T) {
+	srv := NewServer("token")
+	defer srv.Close()
+
+	if err := srv.RecordEvent(1, "jdoe@example.com", gophish.EventEmailOpened); err == nil {
+		t.Error("recorded an event for a missing campaign")
+	}
+}
@@ -0,0 +1,190 @@
+package gophish
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// SplitOptions configures SplitTargets and SplitGroup.
+type SplitOptions struct {
+	// Seed seeds the shuffle, so the same targets and seed always give the
+	// same cohorts.
+	Seed int64
+
+	// StratifyByPosition spreads the targets of each position evenly across
+	// the cohorts, so i.e. executives aren't all in the same cohort.
+	StratifyByPosition bool
+
+	// NameFormat names the cohort groups created by SplitGroup, given the
+	// original group's name and the cohort's number (from 1). It defaults
+	// to "%s - Cohort %d".
+	NameFormat string
+
+	// DryRun makes SplitGroup return the cohorts without creating them.
+	DryRun bool
+}
+
+// SplitTargets randomly splits targets into n cohorts whose sizes differ by
+// at most one.
+func SplitTargets(targets []Target, n int, opts SplitOptions) ([][]Target, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("gophish: can't split into %d cohorts", n)
+	}
+	if n > len(targets) {
+		return nil, fmt.Errorf("gophish: can't split %d targets into %d cohorts", len(targets), n)
+	}
+
+	rnd := rand.New(rand.NewSource(opts.Seed))
+	var strata [][]Target
+	if opts.StratifyByPosition {
+		strata = stratify(targets)
+	} else {
+		strata = [][]Target{canonicalOrder(targets)}
+	}
+
+	// Dealing each shuffled stratum round robin, carrying on where the last
+	// one stopped, keeps both the strata and the cohort sizes balanced.
+	cohorts := make([][]Target, n)
+	next := 0
+	for _, stratum := range strata {
+		rnd.Shuffle(len(stratum), func(i, j int) {
+			stratum[i], stratum[j] = stratum[j], stratum[i]
+		})
+		for _, t := range stratum {
+			cohorts[next] = append(cohorts[next], t)
+			next = (next + 1) % n
+		}
+	}
+	return cohorts, nil
+}
+
+// SampleTargets randomly picks k of the targets, seeded by seed so the same
+// targets and seed always give the same sample.
+func SampleTargets(targets []Target, k int, seed int64) ([]Target, error) {
+	if k < 1 || k > len(targets) {
+		return nil, fmt.Errorf("gophish: can't sample %d of %d targets", k, len(targets))
+	}
+
+	sample := canonicalOrder(targets)
+	rnd := rand.New(rand.NewSource(seed))
+	rnd.Shuffle(len(sample), func(i, j int) {
+		sample[i], sample[j] = sample[j], sample[i]
+	})
+	return sample[:k], nil
+}
+
+// canonicalOrder returns a copy of targets sorted by email address, so that
+// shuffles don't depend on the order gophish happened to return them in.
+func canonicalOrder(targets []Target) []Target {
+	ordered := make([]Target, len(targets))
+	copy(ordered, targets)
+	sortTargets(ordered)
+	return ordered
+}
+
+// stratify groups targets by (case insensitive) position, in order of
+// position.
+func stratify(targets []Target) [][]Target {
+	byPosition := make(map[string][]Target)
+	for _, t := range canonicalOrder(targets) {
+		key := strings.ToLower(strings.TrimSpace(t.Position))
+		byPosition[key] = append(byPosition[key], t)
+	}
+
+	positions := make([]string, 0, len(byPosition))
+	for position := range byPosition {
+		positions = append(positions, position)
+	}
+	sort.Strings(positions)
+
+	strata := make([][]Target, len(positions))
+	for i, position := range positions {
+		strata[i] = byPosition[position]
+	}
+	return strata
+}
+
+// SplitGroup randomly splits the group with the given ID into n balanced
+// cohorts, i.e. for A/B testing templates, creating a new group for each
+// cohort. The original group is left as is.
+func (ss *GroupsService) SplitGroup(id, n int, opts SplitOptions) ([]*Group, error) {
+	return ss.SplitGroupContext(context.Background(), id, n, opts)
+}
+
+// SplitGroupContext is like SplitGroup, but uses the given context.
+func (ss *GroupsService) SplitGroupContext(ctx context.Context, id, n int, opts SplitOptions) ([]*Group, error) {
+	group, err := ss.GetGroupContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	cohorts, err := SplitTargets(group.Targets, n, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	format := opts.NameFormat
+	if format == "" {
+		format = "%s - Cohort %d"
+	}
+	groups := make([]*Group, len(cohorts))
+	for i, cohort := range cohorts {
+		groups[i] = &Group{
+			Name:    fmt.Sprintf(format, group.Name, i+1),
+			Targets: cohort,
+		}
+	}
+	if opts.DryRun {
+		return groups, nil
+	}
+
+	for i := range groups {
+		if groups[i], err = ss.CreateGroupContext(ctx, groups[i]); err != nil {
+			return groups[:i], err
+		}
+	}
+	return groups, nil
+}
+
+// SampleOptions configures SampleGroup.
+type SampleOptions struct {
+	// Seed seeds the sampling, so the same group and seed always give the
+	// same sample.
+	Seed int64
+
+	// Name is the name of the sample group. It defaults to the original
+	// group's name followed by " - Sample".
+	Name string
+
+	// DryRun makes SampleGroup return the sample without creating it.
+	DryRun bool
+}
+
+// SampleGroup creates a new group of k targets randomly picked from the
+// group with the given ID. The original group is left as is.
+func (ss *GroupsService) SampleGroup(id, k int, opts SampleOptions) (*Group, error) {
+	return ss.SampleGroupContext(context.Background(), id, k, opts)
+}
+
+// SampleGroupContext is like SampleGroup, but uses the given context.
+func (ss *GroupsService) SampleGroupContext(ctx context.Context, id, k int, opts SampleOptions) (*Group, error) {
+	group, err := ss.GetGroupContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := SampleTargets(group.Targets, k, opts.Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := &Group{Name: opts.Name, Targets: targets}
+	if sample.Name == "" {
+		sample.Name = group.Name + " - Sample"
+	}
+	if opts.DryRun {
+		return sample, nil
+	}
+	return ss.CreateGroupContext(ctx, sample)
+}
@@ -0,0 +1,247 @@
+package gophish_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ttacon/gophish"
+)
+
+func newTargets(n int, position func(i int) string) []gophish.Target {
+	targets := make([]gophish.Target, n)
+	for i := range targets {
+		targets[i] = gophish.Target{Email: fmt.Sprintf("user%02d@example.com", i)}
+		if position != nil {
+			targets[i].Position = position(i)
+		}
+	}
+	return targets
+}
+
+func reversed(targets []gophish.Target) []gophish.Target {
+	r := make([]gophish.Target, len(targets))
+	for i, t := range targets {
+		r[len(targets)-1-i] = t
+	}
+	return r
+}
+
+func TestSplitTargetsBalanced(t *testing.T) {
+	for _, size := range []int{1, 7, 10, 23} {
+		for n := 1; n <= size && n <= 5; n++ {
+			targets := newTargets(size, nil)
+			cohorts, err := gophish.SplitTargets(targets, n, gophish.SplitOptions{Seed: 42})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(cohorts) != n {
+				t.Fatalf("%d into %d: got %d cohorts", size, n, len(cohorts))
+			}
+
+			seen := make(map[string]int)
+			min, max := size, 0
+			for _, cohort := range cohorts {
+				if len(cohort) < min {
+					min = len(cohort)
+				}
+				if len(cohort) > max {
+					max = len(cohort)
+				}
+				for _, target := range cohort {
+					seen[target.Email]++
+				}
+			}
+			if max-min > 1 {
+				t.Errorf("%d into %d: cohort sizes range from %d to %d", size, n, min, max)
+			}
+			if len(seen) != size {
+				t.Errorf("%d into %d: got %d distinct targets", size, n, len(seen))
+			}
+			for email, count := range seen {
+				if count != 1 {
+					t.Errorf("%d into %d: %s is in %d cohorts", size, n, email, count)
+				}
+			}
+		}
+	}
+}
+
+func TestSplitTargetsReproducible(t *testing.T) {
+	targets := newTargets(20, nil)
+
+	a, err := gophish.SplitTargets(targets, 3, gophish.SplitOptions{Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The order the targets are given in doesn't matter.
+	b, err := gophish.SplitTargets(reversed(targets), 3, gophish.SplitOptions{Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("the same seed gave different cohorts:\n%v\n%v", a, b)
+	}
+
+	c, err := gophish.SplitTargets(targets, 3, gophish.SplitOptions{Seed: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(a, c) {
+		t.Error("different seeds gave the same cohorts")
+	}
+
+	// The input isn't reordered.
+	if !reflect.DeepEqual(targets, newTargets(20, nil)) {
+		t.Error("SplitTargets modified its input")
+	}
+}
+
+func TestSplitTargetsStratified(t *testing.T) {
+	positions := []string{"Executive", "executive ", "Engineer", "Sales"}
+	targets := newTargets(22, func(i int) string {
+		if i < 4 {
+			return positions[i%2] // 4 executives
+		}
+		return positions[2+i%2] // 9 engineers and 9 salespeople
+	})
+
+	for seed := int64(0); seed < 20; seed++ {
+		cohorts, err := gophish.SplitTargets(targets, 4, gophish.SplitOptions{
+			Seed:               seed,
+			StratifyByPosition: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, position := range []string{"executive", "engineer", "sales"} {
+			min, max := len(targets), 0
+			for _, cohort := range cohorts {
+				count := 0
+				for _, target := range cohort {
+					if strings.ToLower(strings.TrimSpace(target.Position)) == position {
+						count++
+					}
+				}
+				if count < min {
+					min = count
+				}
+				if count > max {
+					max = count
+				}
+			}
+			if max-min > 1 {
+				t.Errorf("seed %d: %s counts per cohort range from %d to %d", seed, position, min, max)
+			}
+		}
+
+		min, max := len(targets), 0
+		for _, cohort := range cohorts {
+			if len(cohort) < min {
+				min = len(cohort)
+			}
+			if len(cohort) > max {
+				max = len(cohort)
+			}
+		}
+		if max-min > 1 {
+			t.Errorf("seed %d: cohort sizes range from %d to %d", seed, min, max)
+		}
+	}
+}
+
+func TestSplitTargetsErrors(t *testing.T) {
+	targets := newTargets(3, nil)
+	for _, n := range []int{0, -1, 4} {
+		if _, err := gophish.SplitTargets(targets, n, gophish.SplitOptions{}); err == nil {
+			t.Errorf("splitting 3 targets into %d: got no error", n)
+		}
+	}
+}
+
+func TestSampleTargets(t *testing.T) {
+	targets := newTargets(20, nil)
+
+	sample, err := gophish.SampleTargets(targets, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sample) != 5 {
+		t.Fatalf("got %d targets, want 5", len(sample))
+	}
+	seen := make(map[string]bool)
+	for _, target := range sample {
+		if seen[target.Email] {
+			t.Errorf("%s was sampled twice", target.Email)
+		}
+		seen[target.Email] = true
+	}
+
+	again, err := gophish.SampleTargets(reversed(targets), 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sample, again) {
+		t.Errorf("the same seed gave different samples:\n%v\n%v", sample, again)
+	}
+
+	for _, k := range []int{0, 21} {
+		if _, err := gophish.SampleTargets(targets, k, 3); err == nil {
+			t.Errorf("sampling %d of 20 targets: got no error", k)
+		}
+	}
+}
+
+func TestSplitAndSampleGroup(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	group, err := client.Groups.CreateGroup(&gophish.Group{
+		Name:    "Staff",
+		Targets: newTargets(10, nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dry, err := client.Groups.SplitGroup(group.ID, 3, gophish.SplitOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dry) != 3 || dry[0].ID != 0 || dry[0].Name != "Staff - Cohort 1" {
+		t.Errorf("got %+v, want 3 unsaved cohorts", dry)
+	}
+
+	cohorts, err := client.Groups.SplitGroup(group.ID, 2, gophish.SplitOptions{
+		Seed:       1,
+		NameFormat: "%s (%d)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cohorts) != 2 || cohorts[1].Name != "Staff (2)" || cohorts[1].ID == 0 {
+		t.Errorf("got %+v, want 2 created cohorts", cohorts)
+	}
+
+	sample, err := client.Groups.SampleGroup(group.ID, 4, gophish.SampleOptions{Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sample.ID == 0 || sample.Name != "Staff - Sample" || len(sample.Targets) != 4 {
+		t.Errorf("got %+v, want a created sample of 4", sample)
+	}
+
+	summaries, err := client.Groups.ListGroupSummaries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 4 {
+		t.Errorf("got %d groups, want the original, 2 cohorts and a sample", len(summaries))
+	}
+
+	if _, err := client.Groups.SplitGroup(group.ID+100, 2, gophish.SplitOptions{}); !gophish.IsNotFound(err) {
+		t.Errorf("got error %v splitting a missing group, want not found", err)
+	}
+}
@@ -1,10 +1,16 @@
 package gophish
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
 // Group is a set of recipients that allows us to manage recipients in bulk
@@ -13,7 +19,7 @@ type Group struct {
 	ID           int      `json:"id"`
 	Name         string   `json:"name"`
 	Targets      []Target `json:"targets"`
-	ModifiedDate string   `json:"modified_date"`
+	ModifiedDate Time     `json:"modified_date"`
 }
 
 // Target is a specific Gophish target.
@@ -31,10 +37,16 @@ type GroupsService struct {
 
 // ListGroups returns a list of groups.
 func (ss *GroupsService) ListGroups() ([]Group, error) {
-	resp, err := ss.MakeRequest("GET", "/api/groups", nil)
+	return ss.ListGroupsContext(context.Background())
+}
+
+// ListGroupsContext is like ListGroups, but uses the given context.
+func (ss *GroupsService) ListGroupsContext(ctx context.Context) ([]Group, error) {
+	resp, err := ss.MakeRequestContext(ctx, "GET", "/api/groups", nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var groups []Group
 	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
@@ -43,9 +55,30 @@ func (ss *GroupsService) ListGroups() ([]Group, error) {
 	return groups, nil
 }
 
+// StreamGroups is like ListGroups, but returns an iterator that decodes
+// groups one at a time. The iterator must be closed.
+func (ss *GroupsService) StreamGroups() (*GroupIterator, error) {
+	return ss.StreamGroupsContext(context.Background())
+}
+
+// StreamGroupsContext is like StreamGroups, but uses the given context.
+func (ss *GroupsService) StreamGroupsContext(ctx context.Context) (*GroupIterator, error) {
+	resp, err := ss.MakeRequestContext(ctx, "GET", "/api/groups", nil)
+	if err != nil {
+		return nil, err
+	}
+	return &GroupIterator{stream: newArrayStream(resp.Body)}, nil
+}
+
 // GetGroup retrieves a group given an ID.
 func (ss *GroupsService) GetGroup(id int) (*Group, error) {
-	resp, err := ss.MakeRequest(
+	return ss.GetGroupContext(context.Background(), id)
+}
+
+// GetGroupContext is like GetGroup, but uses the given context.
+func (ss *GroupsService) GetGroupContext(ctx context.Context, id int) (*Group, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("/api/groups/%d", id),
 		nil,
@@ -53,6 +86,7 @@ func (ss *GroupsService) GetGroup(id int) (*Group, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var group Group
 	if err := json.NewDecoder(resp.Body).Decode(&group); err != nil {
@@ -63,10 +97,16 @@ func (ss *GroupsService) GetGroup(id int) (*Group, error) {
 
 // CreateGroup creates a new group.
 func (ss *GroupsService) CreateGroup(sp *Group) (*Group, error) {
-	resp, err := ss.MakeRequest("POST", "/api/groups", sp)
+	return ss.CreateGroupContext(context.Background(), sp)
+}
+
+// CreateGroupContext is like CreateGroup, but uses the given context.
+func (ss *GroupsService) CreateGroupContext(ctx context.Context, sp *Group) (*Group, error) {
+	resp, err := ss.MakeRequestContext(ctx, "POST", "/api/groups", sp)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var group Group
 	if err := json.NewDecoder(resp.Body).Decode(&group); err != nil {
@@ -77,7 +117,13 @@ func (ss *GroupsService) CreateGroup(sp *Group) (*Group, error) {
 
 // UpdateGroup updates the given group.
 func (ss *GroupsService) UpdateGroup(sp *Group) (*Group, error) {
-	resp, err := ss.MakeRequest(
+	return ss.UpdateGroupContext(context.Background(), sp)
+}
+
+// UpdateGroupContext is like UpdateGroup, but uses the given context.
+func (ss *GroupsService) UpdateGroupContext(ctx context.Context, sp *Group) (*Group, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"PUT",
 		fmt.Sprintf("/api/groups/%d", sp.ID),
 		sp,
@@ -85,6 +131,7 @@ func (ss *GroupsService) UpdateGroup(sp *Group) (*Group, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var group Group
 	if err := json.NewDecoder(resp.Body).Decode(&group); err != nil {
@@ -95,7 +142,13 @@ func (ss *GroupsService) UpdateGroup(sp *Group) (*Group, error) {
 
 // DeleteGroup deletes a group given an ID.
 func (ss *GroupsService) DeleteGroup(id int) (bool, error) {
-	resp, err := ss.MakeRequest(
+	return ss.DeleteGroupContext(context.Background(), id)
+}
+
+// DeleteGroupContext is like DeleteGroup, but uses the given context.
+func (ss *GroupsService) DeleteGroupContext(ctx context.Context, id int) (bool, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"DELETE",
 		fmt.Sprintf("/api/groups/%d", id),
 		nil,
@@ -103,37 +156,191 @@ func (ss *GroupsService) DeleteGroup(id int) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	defer resp.Body.Close()
 
 	return resp.StatusCode == http.StatusOK, nil
 }
 
-// ImportGroup imports a group from a CSV - this is not currently supported.
+// ImportTargets uploads a CSV file of targets to gophish to be parsed,
+// returning the targets without saving them to a group. The CSV needs a
+// header row, with the columns "First Name", "Last Name", "Email" and
+// "Position".
+func (ss *GroupsService) ImportTargets(r io.Reader, filename string) ([]Target, error) {
+	return ss.ImportTargetsContext(context.Background(), r, filename)
+}
+
+// ImportTargetsContext is like ImportTargets, but uses the given context.
+func (ss *GroupsService) ImportTargetsContext(ctx context.Context, r io.Reader, filename string) ([]Target, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return nil, err
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	resp, err := ss.do(
+		ctx,
+		"POST",
+		"/api/import/group",
+		form.FormDataContentType(),
+		body.Bytes(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var targets []Target
+	if err := json.NewDecoder(resp.Body).Decode(&targets); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// ImportGroup imports the targets in a CSV file (see ImportTargets). If the
+// request has a Name, the targets are saved to the group with that name,
+// creating it if it doesn't exist, and the saved group is returned.
+// Otherwise, the returned group only holds the imported targets.
 func (ss *GroupsService) ImportGroup(imp ImportGroupRequest) (*Group, error) {
-	return nil, errors.New("importing groups isn't currently supported")
+	return ss.ImportGroupContext(context.Background(), imp)
 }
 
-// ImportGroupRequest is the payload for importing a new group.
+// ImportGroupContext is like ImportGroup, but uses the given context.
+func (ss *GroupsService) ImportGroupContext(ctx context.Context, imp ImportGroupRequest) (*Group, error) {
+	r, filename := imp.Reader, imp.Filename
+	if r == nil {
+		f, err := os.Open(imp.File)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+		if filename == "" {
+			filename = filepath.Base(imp.File)
+		}
+	}
+	if filename == "" {
+		filename = "targets.csv"
+	}
+
+	targets, err := ss.ImportTargetsContext(ctx, r, filename)
+	if err != nil {
+		return nil, err
+	}
+	if imp.Name == "" {
+		return &Group{Targets: targets}, nil
+	}
+
+	existing, err := ss.groupByName(ctx, imp.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return ss.CreateGroupContext(ctx, &Group{
+			Name:    imp.Name,
+			Targets: targets,
+		})
+	}
+
+	if imp.Merge {
+		targets = mergeTargets(existing.Targets, targets)
+	}
+	existing.Targets = targets
+	return ss.UpdateGroupContext(ctx, existing)
+}
+
+// ImportGroupRequest is the payload for importing a group from a CSV file.
 type ImportGroupRequest struct {
+	// File is the path of the CSV file to import, it's ignored if Reader is
+	// set.
 	File string
+
+	// Reader is read for the contents of the CSV file, with Filename as its
+	// name.
+	Reader   io.Reader
+	Filename string
+
+	// Name, if set, is the name of the group to save the targets to.
+	Name string
+
+	// Merge adds the imported targets to the existing group's targets,
+	// rather than replacing them.
+	Merge bool
 }
 
-// ListGroupSummaries returns a list of group summaries.
+// groupByName finds the group with the given name, returning nil if there
+// isn't one. Only the matching group's targets are fetched.
+func (ss *GroupsService) groupByName(ctx context.Context, name string) (*Group, error) {
+	summaries, err := ss.ListGroupSummariesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range summaries {
+		if summary.Name == name {
+			return ss.GetGroupContext(ctx, summary.ID)
+		}
+	}
+	return nil, nil
+}
+
+// mergeTargets adds the given targets to the existing ones. Targets are
+// identified by their (case insensitive) email address, with added targets
+// replacing existing ones.
+func mergeTargets(existing, added []Target) []Target {
+	index := make(map[string]int, len(existing))
+	merged := make([]Target, 0, len(existing)+len(added))
+	for _, t := range existing {
+		index[strings.ToLower(t.Email)] = len(merged)
+		merged = append(merged, t)
+	}
+	for _, t := range added {
+		key := strings.ToLower(t.Email)
+		if i, ok := index[key]; ok {
+			merged[i] = t
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, t)
+	}
+	return merged
+}
+
+// ListGroupSummaries returns a list of group summaries. It's much faster
+// than ListGroups for large groups, as targets aren't included.
 func (ss *GroupsService) ListGroupSummaries() ([]GroupSummary, error) {
-	resp, err := ss.MakeRequest("GET", "/api/groups/summary", nil)
+	return ss.ListGroupSummariesContext(context.Background())
+}
+
+// ListGroupSummariesContext is like ListGroupSummaries, but uses the given context.
+func (ss *GroupsService) ListGroupSummariesContext(ctx context.Context) ([]GroupSummary, error) {
+	resp, err := ss.MakeRequestContext(ctx, "GET", "/api/groups/summary", nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	var groups []GroupSummary
-	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
+	var summaries GroupSummaries
+	if err := json.NewDecoder(resp.Body).Decode(&summaries); err != nil {
 		return nil, err
 	}
-	return groups, nil
+	return summaries.Groups, nil
 }
 
 // GetGroupSummary retrieves a group summary given a group ID.
 func (ss *GroupsService) GetGroupSummary(id int) (*GroupSummary, error) {
-	resp, err := ss.MakeRequest(
+	return ss.GetGroupSummaryContext(context.Background(), id)
+}
+
+// GetGroupSummaryContext is like GetGroupSummary, but uses the given context.
+func (ss *GroupsService) GetGroupSummaryContext(ctx context.Context, id int) (*GroupSummary, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("/api/groups/%d/summary", id),
 		nil,
@@ -141,6 +348,7 @@ func (ss *GroupsService) GetGroupSummary(id int) (*GroupSummary, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var group GroupSummary
 	if err := json.NewDecoder(resp.Body).Decode(&group); err != nil {
@@ -154,6 +362,12 @@ func (ss *GroupsService) GetGroupSummary(id int) (*GroupSummary, error) {
 type GroupSummary struct {
 	ID           int    `json:"id"`
 	Name         string `json:"name"`
-	NumTargets   []int  `json:"num_targets"`
-	ModifiedDate string `json:"modified_date"`
+	NumTargets   int64  `json:"num_targets"`
+	ModifiedDate Time   `json:"modified_date"`
+}
+
+// GroupSummaries is how gophish returns the list of group summaries.
+type GroupSummaries struct {
+	Total  int64          `json:"total"`
+	Groups []GroupSummary `json:"groups"`
 }
@@ -0,0 +1,246 @@
+package gophish_test
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ttacon/gophish"
+)
+
+func TestGroupsCRUD(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	created, err := client.Groups.CreateGroup(&gophish.Group{
+		Name: "Finance",
+		Targets: []gophish.Target{
+			{Email: "jdoe@example.com", FirstName: "John", LastName: "Doe"},
+			{Email: "JDOE@example.com", FirstName: "Duplicate"},
+			{Email: "asmith@example.com", Position: "CFO"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created.Targets) != 2 {
+		t.Errorf("got %d targets, want duplicates dropped", len(created.Targets))
+	}
+
+	got, err := client.Groups.GetGroup(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, created) {
+		t.Errorf("got %+v, want %+v", got, created)
+	}
+
+	got.Targets = append(got.Targets, gophish.Target{Email: "bwayne@example.com"})
+	updated, err := client.Groups.UpdateGroup(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.Targets) != 3 {
+		t.Errorf("got %d targets after updating, want 3", len(updated.Targets))
+	}
+
+	summary, err := client.Groups.GetGroupSummary(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := gophish.GroupSummary{
+		ID:           created.ID,
+		Name:         "Finance",
+		NumTargets:   3,
+		ModifiedDate: gophish.NewTime(now),
+	}
+	if !summary.ModifiedDate.Equal(now) {
+		t.Errorf("got modified date %v, want %v", summary.ModifiedDate, now)
+	}
+	summary.ModifiedDate = want.ModifiedDate
+	if *summary != want {
+		t.Errorf("got summary %+v, want %+v", *summary, want)
+	}
+
+	summaries, err := client.Groups.ListGroupSummaries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 1 || summaries[0].NumTargets != 3 {
+		t.Errorf("got summaries %+v, want one with 3 targets", summaries)
+	}
+
+	for _, invalid := range []gophish.Group{
+		{Name: "Empty"},
+		{Targets: []gophish.Target{{Email: "jdoe@example.com"}}},
+		{Name: "Bad email", Targets: []gophish.Target{{Email: "jdoe"}}},
+	} {
+		if _, err := client.Groups.CreateGroup(&invalid); !gophish.IsBadRequest(err) {
+			t.Errorf("%+v: got error %v, want bad request", invalid, err)
+		}
+	}
+
+	if ok, err := client.Groups.DeleteGroup(created.ID); err != nil || !ok {
+		t.Fatalf("got %v, %v deleting the group", ok, err)
+	}
+	if _, err := client.Groups.GetGroup(created.ID); !gophish.IsNotFound(err) {
+		t.Errorf("got error %v after deleting the group, want not found", err)
+	}
+	if _, err := client.Groups.GetGroupSummary(created.ID); !gophish.IsNotFound(err) {
+		t.Errorf("got error %v getting a deleted group's summary, want not found", err)
+	}
+}
+
+func TestStreamGroups(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	for _, name := range []string{"A", "B", "C"} {
+		if _, err := client.Groups.CreateGroup(&gophish.Group{
+			Name:    name,
+			Targets: []gophish.Target{{Email: strings.ToLower(name) + "@example.com"}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	it, err := client.Groups.StreamGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	var names []string
+	for it.Next() {
+		names = append(names, it.Group().Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names, ",") != "A,B,C" {
+		t.Errorf("got groups %v, want A, B and C", names)
+	}
+}
+
+const targetsCSV = `First Name,Last Name,Email,Position
+John,Doe,jdoe@example.com,Analyst
+Alice,Smith, asmith@example.com ,CFO
+`
+
+func TestImportTargets(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	targets, err := client.Groups.ImportTargets(strings.NewReader(targetsCSV), "targets.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []gophish.Target{
+		{FirstName: "John", LastName: "Doe", Email: "jdoe@example.com", Position: "Analyst"},
+		{FirstName: "Alice", LastName: "Smith", Email: "asmith@example.com", Position: "CFO"},
+	}
+	if !reflect.DeepEqual(targets, want) {
+		t.Errorf("got %+v, want %+v", targets, want)
+	}
+
+	_, err = client.Groups.ImportTargets(strings.NewReader("Email\nnot an email\n"), "targets.csv")
+	if err == nil {
+		t.Error("imported an invalid email address")
+	}
+}
+
+func TestImportGroup(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	// Without a name, nothing is saved.
+	group, err := client.Groups.ImportGroup(gophish.ImportGroupRequest{
+		Reader: strings.NewReader(targetsCSV),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.ID != 0 || len(group.Targets) != 2 {
+		t.Errorf("got %+v, want an unsaved group with 2 targets", group)
+	}
+
+	group, err = client.Groups.ImportGroup(gophish.ImportGroupRequest{
+		Reader: strings.NewReader(targetsCSV),
+		Name:   "Staff",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.ID == 0 || group.Name != "Staff" || len(group.Targets) != 2 {
+		t.Errorf("got %+v, want a new group with 2 targets", group)
+	}
+
+	merged, err := client.Groups.ImportGroup(gophish.ImportGroupRequest{
+		Reader: strings.NewReader("Email,Position\nJDOE@example.com,Manager\nbwayne@example.com,CEO\n"),
+		Name:   "Staff",
+		Merge:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.ID != group.ID {
+		t.Errorf("got group %d, want the existing group %d", merged.ID, group.ID)
+	}
+	var positions []string
+	for _, target := range merged.Targets {
+		positions = append(positions, target.Email+"="+target.Position)
+	}
+	if got, want := strings.Join(positions, ","),
+		"JDOE@example.com=Manager,asmith@example.com=CFO,bwayne@example.com=CEO"; got != want {
+		t.Errorf("got merged targets %s, want %s", got, want)
+	}
+
+	replaced, err := client.Groups.ImportGroup(gophish.ImportGroupRequest{
+		Reader: strings.NewReader("Email\nbwayne@example.com\n"),
+		Name:   "Staff",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replaced.ID != group.ID || len(replaced.Targets) != 1 {
+		t.Errorf("got %+v, want the existing group with 1 target", replaced)
+	}
+}
+
+func TestImportGroupOnlyFetchesMatchingGroup(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	for _, name := range []string{"Other", "Staff"} {
+		if _, err := client.Groups.CreateGroup(&gophish.Group{
+			Name:    name,
+			Targets: []gophish.Target{{Email: "jdoe@example.com"}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var requests []string
+	client = srv.Client(gophish.WithMiddleware(gophish.BeforeRequest(func(req *http.Request) error {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		return nil
+	})))
+	if _, err := client.Groups.ImportGroup(gophish.ImportGroupRequest{
+		Reader: strings.NewReader(targetsCSV),
+		Name:   "Staff",
+		Merge:  true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"POST /api/import/group",
+		"GET /api/groups/summary",
+		"GET /api/groups/2",
+		"PUT /api/groups/2",
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("got requests %q, want %q", requests, want)
+	}
+}
@@ -0,0 +1,207 @@
+package gophish
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GroupDiff is the difference between a group's targets and the targets it
+// should have. Targets are identified by their (case insensitive) email
+// address.
+type GroupDiff struct {
+	Added     []Target
+	Removed   []Target
+	Changed   []TargetChange
+	Unchanged int
+}
+
+// TargetChange is a target whose details (name, position or the case of
+// their email address) changed.
+type TargetChange struct {
+	Old Target
+	New Target
+}
+
+// DiffTargets computes the changes needed to go from the existing targets to
+// the desired ones. Like gophish, only the first of several desired targets
+// with the same email address is kept.
+func DiffTargets(existing, desired []Target) *GroupDiff {
+	current := make(map[string]Target, len(existing))
+	for _, t := range existing {
+		current[strings.ToLower(t.Email)] = t
+	}
+
+	diff := &GroupDiff{}
+	wanted := make(map[string]bool, len(desired))
+	for _, t := range desired {
+		key := strings.ToLower(t.Email)
+		if wanted[key] {
+			continue
+		}
+		wanted[key] = true
+
+		old, ok := current[key]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, t)
+		case old != t:
+			diff.Changed = append(diff.Changed, TargetChange{Old: old, New: t})
+		default:
+			diff.Unchanged++
+		}
+	}
+	for _, t := range existing {
+		if !wanted[strings.ToLower(t.Email)] {
+			diff.Removed = append(diff.Removed, t)
+		}
+	}
+
+	sortTargets(diff.Added)
+	sortTargets(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		a, b := diff.Changed[i].New.Email, diff.Changed[j].New.Email
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+	return diff
+}
+
+// uniqueTargets returns a copy of targets with only the first of several
+// targets with the same (case insensitive) email address.
+func uniqueTargets(targets []Target) []Target {
+	unique := make([]Target, 0, len(targets))
+	seen := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		key := strings.ToLower(t.Email)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+func sortTargets(targets []Target) {
+	sort.Slice(targets, func(i, j int) bool {
+		return strings.ToLower(targets[i].Email) < strings.ToLower(targets[j].Email)
+	})
+}
+
+// Empty reports whether there are no changes.
+func (d *GroupDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String formats the diff for humans, one target per line.
+func (d *GroupDiff) String() string {
+	var sb strings.Builder
+	for _, t := range d.Added {
+		fmt.Fprintf(&sb, "+ %s\n", describeTarget(t))
+	}
+	for _, t := range d.Removed {
+		fmt.Fprintf(&sb, "- %s\n", describeTarget(t))
+	}
+	for _, c := range d.Changed {
+		fmt.Fprintf(&sb, "~ %s -> %s\n", describeTarget(c.Old), describeTarget(c.New))
+	}
+	fmt.Fprintf(
+		&sb,
+		"%d to add, %d to remove, %d to change, %d unchanged\n",
+		len(d.Added),
+		len(d.Removed),
+		len(d.Changed),
+		d.Unchanged,
+	)
+	return sb.String()
+}
+
+func describeTarget(t Target) string {
+	name := strings.TrimSpace(t.FirstName + " " + t.LastName)
+	switch {
+	case name != "" && t.Position != "":
+		return fmt.Sprintf("%s (%s, %s)", t.Email, name, t.Position)
+	case name != "":
+		return fmt.Sprintf("%s (%s)", t.Email, name)
+	case t.Position != "":
+		return fmt.Sprintf("%s (%s)", t.Email, t.Position)
+	}
+	return t.Email
+}
+
+// SyncOptions configures SyncGroup.
+type SyncOptions struct {
+	// DryRun computes the diff without changing anything in gophish.
+	DryRun bool
+}
+
+// SyncResult is the outcome of SyncGroup.
+type SyncResult struct {
+	// Group is the group after the sync. For dry runs, it's the group as it
+	// would be, which has no ID if it would be created.
+	Group *Group
+
+	// Created is whether the group didn't exist (and was created, unless
+	// this is a dry run).
+	Created bool
+
+	Diff *GroupDiff
+}
+
+// SyncGroup makes the group with the given name have exactly the given
+// targets, i.e. to keep it in sync with an authoritative roster. The group is
+// created if it doesn't exist, and only updated if its targets differ.
+func (ss *GroupsService) SyncGroup(name string, targets []Target, opts SyncOptions) (*SyncResult, error) {
+	return ss.SyncGroupContext(context.Background(), name, targets, opts)
+}
+
+// SyncGroupContext is like SyncGroup, but uses the given context.
+func (ss *GroupsService) SyncGroupContext(ctx context.Context, name string, targets []Target, opts SyncOptions) (*SyncResult, error) {
+	if len(targets) == 0 {
+		// An empty roster is far more likely to be a mistake than an intent
+		// to empty the group, which gophish doesn't allow anyway.
+		return nil, fmt.Errorf("gophish: refusing to sync group %q to no targets", name)
+	}
+
+	existing, err := ss.groupByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop duplicates as the diff does, so that it describes what's saved.
+	desired := uniqueTargets(targets)
+
+	if existing == nil {
+		result := &SyncResult{
+			Group:   &Group{Name: name, Targets: desired},
+			Created: true,
+			Diff:    DiffTargets(nil, desired),
+		}
+		if opts.DryRun {
+			return result, nil
+		}
+		if result.Group, err = ss.CreateGroupContext(ctx, result.Group); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	result := &SyncResult{
+		Group: existing,
+		Diff:  DiffTargets(existing.Targets, desired),
+	}
+	if result.Diff.Empty() {
+		return result, nil
+	}
+
+	updated := *existing
+	updated.Targets = desired
+	result.Group = &updated
+	if opts.DryRun {
+		return result, nil
+	}
+	if result.Group, err = ss.UpdateGroupContext(ctx, &updated); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,167 @@
+package gophish_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ttacon/gophish"
+)
+
+func TestDiffTargets(t *testing.T) {
+	existing := []gophish.Target{
+		{Email: "jdoe@example.com", FirstName: "John"},
+		{Email: "asmith@example.com", Position: "CFO"},
+		{Email: "Bwayne@example.com"},
+		{Email: "ckent@example.com"},
+	}
+	desired := []gophish.Target{
+		{Email: "zprince@example.com"},
+		{Email: "JDOE@example.com", FirstName: "John"},
+		{Email: "asmith@example.com", Position: "CEO"},
+		{Email: "bwayne@example.com"},
+		{Email: "aallen@example.com"},
+		{Email: "AALLEN@example.com", FirstName: "Duplicate"},
+	}
+
+	diff := gophish.DiffTargets(existing, desired)
+	want := &gophish.GroupDiff{
+		Added: []gophish.Target{
+			{Email: "aallen@example.com"},
+			{Email: "zprince@example.com"},
+		},
+		Removed: []gophish.Target{
+			{Email: "ckent@example.com"},
+		},
+		Changed: []gophish.TargetChange{
+			{
+				Old: gophish.Target{Email: "asmith@example.com", Position: "CFO"},
+				New: gophish.Target{Email: "asmith@example.com", Position: "CEO"},
+			},
+			{
+				Old: gophish.Target{Email: "Bwayne@example.com"},
+				New: gophish.Target{Email: "bwayne@example.com"},
+			},
+			{
+				Old: gophish.Target{Email: "jdoe@example.com", FirstName: "John"},
+				New: gophish.Target{Email: "JDOE@example.com", FirstName: "John"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("got %+v, want %+v", diff, want)
+	}
+	if diff.Empty() {
+		t.Error("diff is empty")
+	}
+
+	wantString := "+ aallen@example.com\n" +
+		"+ zprince@example.com\n" +
+		"- ckent@example.com\n" +
+		"~ asmith@example.com (CFO) -> asmith@example.com (CEO)\n" +
+		"~ Bwayne@example.com -> bwayne@example.com\n" +
+		"~ jdoe@example.com (John) -> JDOE@example.com (John)\n" +
+		"2 to add, 1 to remove, 3 to change, 0 unchanged\n"
+	if got := diff.String(); got != wantString {
+		t.Errorf("got:\n%s\nwant:\n%s", got, wantString)
+	}
+}
+
+func TestDiffTargetsUnchanged(t *testing.T) {
+	targets := []gophish.Target{
+		{Email: "jdoe@example.com"},
+		{Email: "asmith@example.com"},
+	}
+	diff := gophish.DiffTargets(targets, []gophish.Target{targets[1], targets[0]})
+	if !diff.Empty() || diff.Unchanged != 2 {
+		t.Errorf("got %+v, want 2 unchanged targets", diff)
+	}
+}
+
+func TestSyncGroup(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	roster := []gophish.Target{
+		{Email: "jdoe@example.com"},
+		{Email: "asmith@example.com"},
+	}
+
+	// Dry runs don't create the group.
+	result, err := client.Groups.SyncGroup("Staff", roster, gophish.SyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Created || result.Group.ID != 0 || len(result.Diff.Added) != 2 {
+		t.Errorf("got %+v, want a group to be created", result)
+	}
+	if summaries, err := client.Groups.ListGroupSummaries(); err != nil || len(summaries) != 0 {
+		t.Fatalf("got %v, %v after a dry run, want no groups", summaries, err)
+	}
+
+	created, err := client.Groups.SyncGroup("Staff", roster, gophish.SyncOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created.Created || created.Group.ID == 0 {
+		t.Errorf("got %+v, want the group created", created)
+	}
+
+	unchanged, err := client.Groups.SyncGroup("Staff", roster, gophish.SyncOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unchanged.Created || !unchanged.Diff.Empty() || unchanged.Diff.Unchanged != 2 {
+		t.Errorf("got %+v, want no changes", unchanged)
+	}
+
+	roster = append(roster[1:], gophish.Target{Email: "bwayne@example.com"})
+	updated, err := client.Groups.SyncGroup("Staff", roster, gophish.SyncOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Group.ID != created.Group.ID ||
+		len(updated.Diff.Added) != 1 ||
+		len(updated.Diff.Removed) != 1 {
+		t.Errorf("got %+v, want one target added and removed", updated)
+	}
+
+	group, err := client.Groups.GetGroup(created.Group.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var emails []string
+	for _, target := range group.Targets {
+		emails = append(emails, target.Email)
+	}
+	if got := strings.Join(emails, ","); got != "asmith@example.com,bwayne@example.com" {
+		t.Errorf("got targets %s after syncing", got)
+	}
+
+	// Only the first of several targets with the same email is saved, as
+	// the diff says.
+	roster = append(roster, gophish.Target{Email: "BWAYNE@example.com", FirstName: "Duplicate"})
+	deduped, err := client.Groups.SyncGroup("Staff", roster, gophish.SyncOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deduped.Diff.Empty() || deduped.Diff.Unchanged != 2 {
+		t.Errorf("got diff %+v, want the duplicate ignored", deduped.Diff)
+	}
+	created, err = client.Groups.SyncGroup("Interns", roster, gophish.SyncOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created.Group.Targets) != 2 || len(created.Diff.Added) != 2 {
+		t.Errorf("got group %+v and diff %+v, want 2 targets", created.Group, created.Diff)
+	}
+	for _, target := range created.Group.Targets {
+		if target.FirstName == "Duplicate" {
+			t.Errorf("the duplicate %+v was saved", target)
+		}
+	}
+
+	if _, err := client.Groups.SyncGroup("Staff", nil, gophish.SyncOptions{}); err == nil {
+		t.Error("synced a group to no targets")
+	}
+}
@@ -0,0 +1,121 @@
+package gophish
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+//go:generate mockgen -source=interfaces.go -destination=gophishmock/mocks.go -package=gophishmock
+
+// SendingProfilesAPI is the set of operations on sending profiles, implemented by
+// *SendingProfilesService, so that code using it can be tested with a mock.
+type SendingProfilesAPI interface {
+	ListSendingProfiles() ([]SendingProfile, error)
+	ListSendingProfilesContext(ctx context.Context) ([]SendingProfile, error)
+	GetSendingProfile(id int) (*SendingProfile, error)
+	GetSendingProfileContext(ctx context.Context, id int) (*SendingProfile, error)
+	CreateSendingProfile(profile *SendingProfile) (*SendingProfile, error)
+	CreateSendingProfileContext(ctx context.Context, profile *SendingProfile) (*SendingProfile, error)
+	UpdateSendingProfile(profile *SendingProfile) (*SendingProfile, error)
+	UpdateSendingProfileContext(ctx context.Context, profile *SendingProfile) (*SendingProfile, error)
+	DeleteSendingProfile(id int) (bool, error)
+	DeleteSendingProfileContext(ctx context.Context, id int) (bool, error)
+}
+
+// TemplatesAPI is the set of operations on templates, implemented by
+// *TemplatesService, so that code using it can be tested with a mock.
+type TemplatesAPI interface {
+	ListTemplates() ([]Template, error)
+	ListTemplatesContext(ctx context.Context) ([]Template, error)
+	GetTemplate(id int) (*Template, error)
+	GetTemplateContext(ctx context.Context, id int) (*Template, error)
+	CreateTemplate(template *Template) (*Template, error)
+	CreateTemplateContext(ctx context.Context, template *Template) (*Template, error)
+	UpdateTemplate(template *Template) (*Template, error)
+	UpdateTemplateContext(ctx context.Context, template *Template) (*Template, error)
+	DeleteTemplate(id int) (bool, error)
+	DeleteTemplateContext(ctx context.Context, id int) (bool, error)
+	ImportTemplate(imp ImportRequest) (*Template, error)
+	ImportTemplateContext(ctx context.Context, imp ImportRequest) (*Template, error)
+}
+
+// LandingPagesAPI is the set of operations on landing pages, implemented by
+// *LandingPagesService, so that code using it can be tested with a mock.
+type LandingPagesAPI interface {
+	ListLandingPages() ([]LandingPage, error)
+	ListLandingPagesContext(ctx context.Context) ([]LandingPage, error)
+	GetLandingPage(id int) (*LandingPage, error)
+	GetLandingPageContext(ctx context.Context, id int) (*LandingPage, error)
+	CreateLandingPage(landingPage *LandingPage) (*LandingPage, error)
+	CreateLandingPageContext(ctx context.Context, landingPage *LandingPage) (*LandingPage, error)
+	UpdateLandingPage(landingPage *LandingPage) (*LandingPage, error)
+	UpdateLandingPageContext(ctx context.Context, landingPage *LandingPage) (*LandingPage, error)
+	DeleteLandingPage(id int) (bool, error)
+	DeleteLandingPageContext(ctx context.Context, id int) (bool, error)
+	ImportSite(imp ImportSiteRequest) (*LandingPage, error)
+	ImportSiteContext(ctx context.Context, imp ImportSiteRequest) (*LandingPage, error)
+}
+
+// GroupsAPI is the set of operations on groups, implemented by
+// *GroupsService, so that code using it can be tested with a mock.
+type GroupsAPI interface {
+	ListGroups() ([]Group, error)
+	ListGroupsContext(ctx context.Context) ([]Group, error)
+	StreamGroups() (*GroupIterator, error)
+	StreamGroupsContext(ctx context.Context) (*GroupIterator, error)
+	GetGroup(id int) (*Group, error)
+	GetGroupContext(ctx context.Context, id int) (*Group, error)
+	CreateGroup(group *Group) (*Group, error)
+	CreateGroupContext(ctx context.Context, group *Group) (*Group, error)
+	UpdateGroup(group *Group) (*Group, error)
+	UpdateGroupContext(ctx context.Context, group *Group) (*Group, error)
+	DeleteGroup(id int) (bool, error)
+	DeleteGroupContext(ctx context.Context, id int) (bool, error)
+	ImportTargets(r io.Reader, filename string) ([]Target, error)
+	ImportTargetsContext(ctx context.Context, r io.Reader, filename string) ([]Target, error)
+	ImportGroup(imp ImportGroupRequest) (*Group, error)
+	ImportGroupContext(ctx context.Context, imp ImportGroupRequest) (*Group, error)
+	ListGroupSummaries() ([]GroupSummary, error)
+	ListGroupSummariesContext(ctx context.Context) ([]GroupSummary, error)
+	GetGroupSummary(id int) (*GroupSummary, error)
+	GetGroupSummaryContext(ctx context.Context, id int) (*GroupSummary, error)
+	SyncGroup(name string, targets []Target, opts SyncOptions) (*SyncResult, error)
+	SyncGroupContext(ctx context.Context, name string, targets []Target, opts SyncOptions) (*SyncResult, error)
+	SplitGroup(id, n int, opts SplitOptions) ([]*Group, error)
+	SplitGroupContext(ctx context.Context, id, n int, opts SplitOptions) ([]*Group, error)
+	SampleGroup(id, k int, opts SampleOptions) (*Group, error)
+	SampleGroupContext(ctx context.Context, id, k int, opts SampleOptions) (*Group, error)
+}
+
+// CampaignsAPI is the set of operations on campaigns, implemented by
+// *CampaignsService, so that code using it can be tested with a mock.
+type CampaignsAPI interface {
+	ListCampaigns() ([]Campaign, error)
+	ListCampaignsContext(ctx context.Context) ([]Campaign, error)
+	StreamCampaigns() (*CampaignIterator, error)
+	StreamCampaignsContext(ctx context.Context) (*CampaignIterator, error)
+	GetCampaign(id int) (*Campaign, error)
+	GetCampaignContext(ctx context.Context, id int) (*Campaign, error)
+	GetCampaignResults(id int) (*Campaign, error)
+	GetCampaignResultsContext(ctx context.Context, id int) (*Campaign, error)
+	GetCampaignSummary(id int) (*Campaign, error)
+	GetCampaignSummaryContext(ctx context.Context, id int) (*Campaign, error)
+	CreateCampaign(campaign *Campaign) (*Campaign, error)
+	CreateCampaignContext(ctx context.Context, campaign *Campaign) (*Campaign, error)
+	UpdateCampaign(campaign *Campaign) (*Campaign, error)
+	UpdateCampaignContext(ctx context.Context, campaign *Campaign) (*Campaign, error)
+	DeleteCampaign(id int) (bool, error)
+	DeleteCampaignContext(ctx context.Context, id int) (bool, error)
+	CompleteCampaign(id int) (*CompleteCampaignResponse, error)
+	CompleteCampaignContext(ctx context.Context, id int) (*CompleteCampaignResponse, error)
+	WaitForCampaignStatus(ctx context.Context, id int, status CampaignStatus, interval time.Duration) (*Campaign, error)
+}
+
+var (
+	_ SendingProfilesAPI = (*SendingProfilesService)(nil)
+	_ TemplatesAPI       = (*TemplatesService)(nil)
+	_ LandingPagesAPI    = (*LandingPagesService)(nil)
+	_ GroupsAPI          = (*GroupsService)(nil)
+	_ CampaignsAPI       = (*CampaignsService)(nil)
+)
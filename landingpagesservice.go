@@ -1,6 +1,7 @@
 package gophish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -14,7 +15,7 @@ type LandingPage struct {
 	HTML               string `json:"html"`
 	CaptureCredentials bool   `json:"capture_credentials"`
 	CapturePasswords   bool   `json:"capture_passwords"`
-	ModifiedDate       string `json:"modified_date"`
+	ModifiedDate       Time   `json:"modified_date"`
 	RedirectURL        string `json:"redirect_url"`
 }
 
@@ -25,10 +26,16 @@ type LandingPagesService struct {
 
 // ListLandingPages returns a list of landing pages.
 func (ss *LandingPagesService) ListLandingPages() ([]LandingPage, error) {
-	resp, err := ss.MakeRequest("GET", "/api/pages", nil)
+	return ss.ListLandingPagesContext(context.Background())
+}
+
+// ListLandingPagesContext is like ListLandingPages, but uses the given context.
+func (ss *LandingPagesService) ListLandingPagesContext(ctx context.Context) ([]LandingPage, error) {
+	resp, err := ss.MakeRequestContext(ctx, "GET", "/api/pages", nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var landingpages []LandingPage
 	if err := json.NewDecoder(resp.Body).Decode(&landingpages); err != nil {
@@ -39,7 +46,13 @@ func (ss *LandingPagesService) ListLandingPages() ([]LandingPage, error) {
 
 // GetLandingPage returns a landing page given an ID.
 func (ss *LandingPagesService) GetLandingPage(id int) (*LandingPage, error) {
-	resp, err := ss.MakeRequest(
+	return ss.GetLandingPageContext(context.Background(), id)
+}
+
+// GetLandingPageContext is like GetLandingPage, but uses the given context.
+func (ss *LandingPagesService) GetLandingPageContext(ctx context.Context, id int) (*LandingPage, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("/api/pages/%d", id),
 		nil,
@@ -47,6 +60,7 @@ func (ss *LandingPagesService) GetLandingPage(id int) (*LandingPage, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var profile LandingPage
 	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
@@ -57,10 +71,16 @@ func (ss *LandingPagesService) GetLandingPage(id int) (*LandingPage, error) {
 
 // CreateLandingPage creates a new landing page.
 func (ss *LandingPagesService) CreateLandingPage(sp *LandingPage) (*LandingPage, error) {
-	resp, err := ss.MakeRequest("POST", "/api/pages", sp)
+	return ss.CreateLandingPageContext(context.Background(), sp)
+}
+
+// CreateLandingPageContext is like CreateLandingPage, but uses the given context.
+func (ss *LandingPagesService) CreateLandingPageContext(ctx context.Context, sp *LandingPage) (*LandingPage, error) {
+	resp, err := ss.MakeRequestContext(ctx, "POST", "/api/pages", sp)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var profile LandingPage
 	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
@@ -71,7 +91,13 @@ func (ss *LandingPagesService) CreateLandingPage(sp *LandingPage) (*LandingPage,
 
 // UpdateLandingPage updates the given landing page.
 func (ss *LandingPagesService) UpdateLandingPage(sp *LandingPage) (*LandingPage, error) {
-	resp, err := ss.MakeRequest(
+	return ss.UpdateLandingPageContext(context.Background(), sp)
+}
+
+// UpdateLandingPageContext is like UpdateLandingPage, but uses the given context.
+func (ss *LandingPagesService) UpdateLandingPageContext(ctx context.Context, sp *LandingPage) (*LandingPage, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"PUT",
 		fmt.Sprintf("/api/pages/%d", sp.ID),
 		sp,
@@ -79,6 +105,7 @@ func (ss *LandingPagesService) UpdateLandingPage(sp *LandingPage) (*LandingPage,
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var landingpage LandingPage
 	if err := json.NewDecoder(resp.Body).Decode(&landingpage); err != nil {
@@ -89,7 +116,13 @@ func (ss *LandingPagesService) UpdateLandingPage(sp *LandingPage) (*LandingPage,
 
 // DeleteLandingPage deletes a landing page given an ID.
 func (ss *LandingPagesService) DeleteLandingPage(id int) (bool, error) {
-	resp, err := ss.MakeRequest(
+	return ss.DeleteLandingPageContext(context.Background(), id)
+}
+
+// DeleteLandingPageContext is like DeleteLandingPage, but uses the given context.
+func (ss *LandingPagesService) DeleteLandingPageContext(ctx context.Context, id int) (bool, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"DELETE",
 		fmt.Sprintf("/api/pages/%d", id),
 		nil,
@@ -97,6 +130,7 @@ func (ss *LandingPagesService) DeleteLandingPage(id int) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	defer resp.Body.Close()
 
 	return resp.StatusCode == http.StatusOK, nil
 }
@@ -111,7 +145,13 @@ func (ss *LandingPagesService) DeleteLandingPage(id int) (bool, error) {
 // input, __original_url, that points to the original URL. This makes it
 // possible to replay captured credentials later.
 func (ss *LandingPagesService) ImportSite(imp ImportSiteRequest) (*LandingPage, error) {
-	resp, err := ss.MakeRequest(
+	return ss.ImportSiteContext(context.Background(), imp)
+}
+
+// ImportSiteContext is like ImportSite, but uses the given context.
+func (ss *LandingPagesService) ImportSiteContext(ctx context.Context, imp ImportSiteRequest) (*LandingPage, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"POST",
 		"/api/import/site",
 		imp,
@@ -119,6 +159,7 @@ func (ss *LandingPagesService) ImportSite(imp ImportSiteRequest) (*LandingPage,
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var landingpage LandingPage
 	if err := json.NewDecoder(resp.Body).Decode(&landingpage); err != nil {
@@ -0,0 +1,100 @@
+package gophish_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ttacon/gophish"
+)
+
+func TestLandingPagesCRUD(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	created, err := client.LandingPages.CreateLandingPage(&gophish.LandingPage{
+		Name:             "Login",
+		HTML:             "<form></form>",
+		CapturePasswords: true,
+		RedirectURL:      "https://example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.CapturePasswords {
+		t.Error("passwords are captured without capturing credentials")
+	}
+
+	got, err := client.LandingPages.GetLandingPage(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, created) {
+		t.Errorf("got %+v, want %+v", got, created)
+	}
+
+	got.CaptureCredentials = true
+	got.CapturePasswords = true
+	updated, err := client.LandingPages.UpdateLandingPage(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !updated.CaptureCredentials || !updated.CapturePasswords {
+		t.Errorf("got %+v after updating", updated)
+	}
+
+	if _, err := client.LandingPages.CreateLandingPage(&gophish.LandingPage{}); !gophish.IsBadRequest(err) {
+		t.Errorf("got error %v creating a nameless page, want bad request", err)
+	}
+
+	list, err := client.LandingPages.ListLandingPages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].ID != created.ID {
+		t.Errorf("got %+v, want the created page", list)
+	}
+
+	if ok, err := client.LandingPages.DeleteLandingPage(created.ID); err != nil || !ok {
+		t.Fatalf("got %v, %v deleting the page", ok, err)
+	}
+	if _, err := client.LandingPages.GetLandingPage(created.ID); !gophish.IsNotFound(err) {
+		t.Errorf("got error %v after deleting the page, want not found", err)
+	}
+}
+
+func TestImportSite(t *testing.T) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<html><head><title>Login</title></head><body></body></html>")
+	}))
+	defer site.Close()
+
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	page, err := client.LandingPages.ImportSite(gophish.ImportSiteRequest{URL: site.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(page.HTML, `<head><base href="`+site.URL+`">`) {
+		t.Errorf("got %q, want a <base> tag", page.HTML)
+	}
+
+	page, err = client.LandingPages.ImportSite(gophish.ImportSiteRequest{
+		URL:              site.URL,
+		IncludeResources: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(page.HTML, "<base") {
+		t.Errorf("got %q, want no <base> tag", page.HTML)
+	}
+
+	if _, err := client.LandingPages.ImportSite(gophish.ImportSiteRequest{}); !gophish.IsBadRequest(err) {
+		t.Errorf("got error %v importing without a URL, want bad request", err)
+	}
+}
@@ -0,0 +1,195 @@
+package gophish
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Limiter throttles the requests made by a client, using a token bucket to
+// bound the request rate and a semaphore to bound how many requests are in
+// flight at once. A request is in flight until gophish responds with its
+// headers. A Limiter is safe for concurrent use and can be shared between
+// several clients with WithLimiter.
+type Limiter struct {
+	rate  float64
+	burst int
+	sem   chan struct{}
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	stats  LimiterStats
+}
+
+// LimiterStats reports how much requests have been throttled by a Limiter.
+type LimiterStats struct {
+	// Requests is the number of requests that went through the limiter.
+	Requests int64
+
+	// Throttled is the number of requests that had to wait.
+	Throttled int64
+
+	// TotalWait and MaxWait are the sum and maximum of the time requests
+	// spent waiting.
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// NewLimiter creates a Limiter allowing rate requests per second with bursts
+// of up to burst requests, and at most maxInFlight concurrent requests. A
+// rate or maxInFlight of 0 leaves that dimension unlimited.
+func NewLimiter(rate float64, burst, maxInFlight int) *Limiter {
+	l := &Limiter{}
+	l.setRate(rate, burst)
+	l.setMaxInFlight(maxInFlight)
+	return l
+}
+
+// WithLimiter throttles every request made by the client with the given
+// Limiter.
+func WithLimiter(l *Limiter) Option {
+	return func(s *Service) error {
+		if l == nil {
+			return errors.New("gophish: nil limiter")
+		}
+		s.Limiter = l
+		return nil
+	}
+}
+
+// WithRateLimit limits the client to rate requests per second, with bursts of
+// up to burst requests.
+func WithRateLimit(rate float64, burst int) Option {
+	return func(s *Service) error {
+		if rate < 0 || burst < 0 {
+			return errors.New("gophish: rate limit must not be negative")
+		}
+		if s.Limiter == nil {
+			s.Limiter = &Limiter{}
+		}
+		s.Limiter.setRate(rate, burst)
+		return nil
+	}
+}
+
+// WithMaxInFlight limits the client to n concurrent requests.
+func WithMaxInFlight(n int) Option {
+	return func(s *Service) error {
+		if n < 0 {
+			return errors.New("gophish: max in flight must not be negative")
+		}
+		if s.Limiter == nil {
+			s.Limiter = &Limiter{}
+		}
+		s.Limiter.setMaxInFlight(n)
+		return nil
+	}
+}
+
+func (l *Limiter) setRate(rate float64, burst int) {
+	if burst < 1 {
+		burst = 1
+	}
+	l.rate = rate
+	l.burst = burst
+	l.tokens = float64(burst)
+}
+
+func (l *Limiter) setMaxInFlight(n int) {
+	l.sem = nil
+	if n > 0 {
+		l.sem = make(chan struct{}, n)
+	}
+}
+
+// Wait blocks until a request is allowed to proceed, or the context is done.
+// On success, the returned function must be called once the request is no
+// longer in flight.
+func (l *Limiter) Wait(ctx context.Context) (release func(), err error) {
+	start := time.Now()
+
+	release = func() {}
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() {
+			once.Do(func() { <-l.sem })
+		}
+	}
+
+	if d := l.reserve(); d > 0 {
+		if err := sleep(ctx, d); err != nil {
+			l.cancelReservation()
+			release()
+			return nil, err
+		}
+	}
+
+	l.record(time.Since(start))
+	return release, nil
+}
+
+// Stats returns a snapshot of the limiter's statistics.
+func (l *Limiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// reserve takes a token from the bucket, returning how long the caller has
+// to wait before the token is actually available.
+func (l *Limiter) reserve() time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > float64(l.burst) {
+			l.tokens = float64(l.burst)
+		}
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancelReservation returns a token taken by reserve that ended up unused.
+func (l *Limiter) cancelReservation() {
+	if l.rate <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+}
+
+func (l *Limiter) record(wait time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stats.Requests++
+	// Ignore the bookkeeping overhead of requests that didn't need to wait.
+	if wait < time.Millisecond {
+		return
+	}
+	l.stats.Throttled++
+	l.stats.TotalWait += wait
+	if wait > l.stats.MaxWait {
+		l.stats.MaxWait = wait
+	}
+}
@@ -0,0 +1,150 @@
+package gophish
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiterBurst(t *testing.T) {
+	l := NewLimiter(10, 3, 0)
+
+	for i := 0; i < 3; i++ {
+		start := time.Now()
+		release, err := l.Wait(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+		if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+			t.Errorf("request %d of the burst waited %v", i, elapsed)
+		}
+	}
+
+	start := time.Now()
+	release, err := l.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("request after the burst waited %v, want ~100ms", elapsed)
+	}
+
+	stats := l.Stats()
+	if stats.Requests != 4 {
+		t.Errorf("got %d requests, want 4", stats.Requests)
+	}
+	if stats.Throttled != 1 {
+		t.Errorf("got %d throttled requests, want 1", stats.Throttled)
+	}
+	if stats.TotalWait < 80*time.Millisecond || stats.TotalWait > time.Second {
+		t.Errorf("got total wait %v, want ~100ms", stats.TotalWait)
+	}
+	if stats.MaxWait != stats.TotalWait {
+		t.Errorf("got max wait %v, want %v", stats.MaxWait, stats.TotalWait)
+	}
+}
+
+func TestLimiterUnlimited(t *testing.T) {
+	l := NewLimiter(0, 0, 0)
+	for i := 0; i < 100; i++ {
+		release, err := l.Wait(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	if stats := l.Stats(); stats.Requests != 100 || stats.Throttled != 0 {
+		t.Errorf("got %+v, want 100 unthrottled requests", stats)
+	}
+}
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	l := NewLimiter(0, 0, 2)
+
+	var releases []func()
+	for i := 0; i < 2; i++ {
+		release, err := l.Wait(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		releases = append(releases, release)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("got error %v with 2 requests in flight, want %v", err, context.DeadlineExceeded)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		release, err := l.Wait(context.Background())
+		if err != nil {
+			t.Error(err)
+		} else {
+			release()
+		}
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("request proceeded with 2 requests in flight")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Releasing twice must not free up a second slot.
+	releases[0]()
+	releases[0]()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("request didn't proceed after a release")
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	release, err := l.Wait(ctx)
+	if err != nil {
+		t.Fatalf("got error %v with 1 request in flight", err)
+	}
+	release()
+	releases[1]()
+}
+
+func TestLimiterCancelReturnsToken(t *testing.T) {
+	l := NewLimiter(1, 1, 1)
+
+	release, err := l.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	l.mu.Lock()
+	tokens := l.tokens
+	l.mu.Unlock()
+	if tokens < -0.5 {
+		t.Errorf("got %.2f tokens after a cancelled wait, want the token to be returned", tokens)
+	}
+
+	// The cancelled request neither counts as a request nor holds on to its
+	// slot.
+	if stats := l.Stats(); stats.Requests != 1 {
+		t.Errorf("got %d requests, want 1", stats.Requests)
+	}
+	select {
+	case l.sem <- struct{}{}:
+		<-l.sem
+	default:
+		t.Error("cancelled request is still in flight")
+	}
+}
@@ -0,0 +1,65 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ttacon/gophish"
+)
+
+// Apply makes the changes of the plan, in order, stopping at the first that
+// fails.
+func (p *Plan) Apply(ctx context.Context, client *gophish.Client) error {
+	for _, c := range p.Changes {
+		if err := c.apply(ctx, client); err != nil {
+			return fmt.Errorf("manifest: %s %s %q: %v", c.Action, c.Kind, c.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *Change) apply(ctx context.Context, client *gophish.Client) error {
+	var err error
+	switch c.Action {
+	case ActionCreate:
+		switch desired := c.desired.(type) {
+		case gophish.SendingProfile:
+			_, err = client.SendingProfiles.CreateSendingProfileContext(ctx, &desired)
+		case gophish.Template:
+			_, err = client.Templates.CreateTemplateContext(ctx, &desired)
+		case gophish.LandingPage:
+			_, err = client.LandingPages.CreateLandingPageContext(ctx, &desired)
+		case gophish.Group:
+			_, err = client.Groups.CreateGroupContext(ctx, &desired)
+		case *CampaignSpec:
+			_, err = client.Campaigns.CreateCampaignContext(ctx, desired.Campaign())
+		}
+	case ActionUpdate:
+		switch desired := c.desired.(type) {
+		case gophish.SendingProfile:
+			desired.ID = c.ID
+			_, err = client.SendingProfiles.UpdateSendingProfileContext(ctx, &desired)
+		case gophish.Template:
+			desired.ID = c.ID
+			_, err = client.Templates.UpdateTemplateContext(ctx, &desired)
+		case gophish.LandingPage:
+			desired.ID = c.ID
+			_, err = client.LandingPages.UpdateLandingPageContext(ctx, &desired)
+		case gophish.Group:
+			desired.ID = c.ID
+			_, err = client.Groups.UpdateGroupContext(ctx, &desired)
+		}
+	case ActionDelete:
+		switch c.Kind {
+		case KindSendingProfile:
+			_, err = client.SendingProfiles.DeleteSendingProfileContext(ctx, c.ID)
+		case KindTemplate:
+			_, err = client.Templates.DeleteTemplateContext(ctx, c.ID)
+		case KindLandingPage:
+			_, err = client.LandingPages.DeleteLandingPageContext(ctx, c.ID)
+		case KindGroup:
+			_, err = client.Groups.DeleteGroupContext(ctx, c.ID)
+		}
+	}
+	return err
+}
@@ -0,0 +1,381 @@
+// Package manifest manages gophish resources declaratively: manifests (YAML
+// files in git, say) describe the sending profiles, templates, landing pages,
+// groups and campaigns an instance should have, and a Plan brings the
+// instance in line with them.
+//
+// Each YAML document of a manifest is one resource, with its kind and the
+// same fields the gophish API uses:
+//
+//	kind: Template
+//	name: Invoice
+//	subject: Your invoice is overdue
+//	html: <p>Hi {{.FirstName}}, ...</p>
+//	---
+//	kind: Campaign
+//	name: Q4 invoices
+//	template: Invoice
+//	page: O365 login
+//	smtp: Corp SMTP
+//	groups: [Finance]
+//	url: https://phish.example.com
+//
+// Resources are identified by name, and campaigns refer to the other
+// resources by name.
+//
+// Sending profiles without a password keep the one they have. Rather than
+// giving it with password, a sending profile can read it from an environment
+// variable with password_env or from a file with password_file, so that it
+// needn't be kept with the manifest.
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ttacon/gophish"
+	"gopkg.in/yaml.v2"
+)
+
+// The kinds of resources.
+const (
+	KindSendingProfile = "SendingProfile"
+	KindTemplate       = "Template"
+	KindLandingPage    = "LandingPage"
+	KindGroup          = "Group"
+	KindCampaign       = "Campaign"
+)
+
+// kinds are all the kinds in the order they're created, which is the order
+// campaigns need.
+var kinds = []string{
+	KindSendingProfile,
+	KindTemplate,
+	KindLandingPage,
+	KindGroup,
+	KindCampaign,
+}
+
+// CampaignSpec is a campaign as given in manifests, referring to its
+// template, landing page, sending profile and groups by name.
+type CampaignSpec struct {
+	Name       string       `json:"name"`
+	Template   string       `json:"template"`
+	Page       string       `json:"page"`
+	SMTP       string       `json:"smtp"`
+	Groups     []string     `json:"groups"`
+	URL        string       `json:"url"`
+	LaunchDate gophish.Time `json:"launch_date"`
+	SendByDate gophish.Time `json:"send_by_date"`
+}
+
+// Campaign returns the campaign to create for the spec.
+func (cs *CampaignSpec) Campaign() *gophish.Campaign {
+	c := &gophish.Campaign{
+		Name:       cs.Name,
+		Template:   gophish.Template{Name: cs.Template},
+		Page:       gophish.LandingPage{Name: cs.Page},
+		SMTP:       gophish.SendingProfile{Name: cs.SMTP},
+		URL:        cs.URL,
+		LaunchDate: cs.LaunchDate,
+		SendByDate: cs.SendByDate,
+	}
+	for _, name := range cs.Groups {
+		c.Groups = append(c.Groups, gophish.Group{Name: name})
+	}
+	return c
+}
+
+// Manifest is a set of resources.
+type Manifest struct {
+	SendingProfiles []gophish.SendingProfile
+	Templates       []gophish.Template
+	LandingPages    []gophish.LandingPage
+	Groups          []gophish.Group
+	Campaigns       []CampaignSpec
+}
+
+// Load reads manifests from the given files and directories. Directories are
+// read recursively for .yaml, .yml and .json files, in lexical order.
+func Load(paths ...string) (*Manifest, error) {
+	m := &Manifest{}
+	for _, path := range paths {
+		files, err := manifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			f, err := os.Open(file)
+			if err != nil {
+				return nil, err
+			}
+			err = m.read(f, file)
+			f.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return m, m.Validate()
+}
+
+func manifestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".yaml", ".yml", ".json":
+			if !info.IsDir() {
+				files = append(files, file)
+			}
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// Parse reads the resources of a manifest, named name in errors.
+func Parse(r io.Reader, name string) (*Manifest, error) {
+	m := &Manifest{}
+	if err := m.read(r, name); err != nil {
+		return nil, err
+	}
+	return m, m.Validate()
+}
+
+// read adds the resources of a manifest to m.
+func (m *Manifest) read(r io.Reader, name string) error {
+	dec := yaml.NewDecoder(r)
+	for doc := 1; ; doc++ {
+		var v interface{}
+		err := dec.Decode(&v)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		if v == nil {
+			// An empty document, i.e. after a trailing "---".
+			continue
+		}
+		if err := m.add(JSONValue(v)); err != nil {
+			return fmt.Errorf("%s (document %d): %v", name, doc, err)
+		}
+	}
+}
+
+func (m *Manifest) add(v interface{}) error {
+	fields, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected a resource, got %T", v)
+	}
+	kind, _ := fields["kind"].(string)
+	delete(fields, "kind")
+	var password string
+	if strings.EqualFold(kind, KindSendingProfile) {
+		var err error
+		if password, err = readPassword(fields); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case strings.EqualFold(kind, KindSendingProfile):
+		var sp gophish.SendingProfile
+		err = decodeStrict(data, &sp)
+		if password != "" {
+			sp.Password = password
+		}
+		m.SendingProfiles = append(m.SendingProfiles, sp)
+	case strings.EqualFold(kind, KindTemplate):
+		var t gophish.Template
+		err = decodeStrict(data, &t)
+		m.Templates = append(m.Templates, t)
+	case strings.EqualFold(kind, KindLandingPage):
+		var p gophish.LandingPage
+		err = decodeStrict(data, &p)
+		m.LandingPages = append(m.LandingPages, p)
+	case strings.EqualFold(kind, KindGroup):
+		var g gophish.Group
+		err = decodeStrict(data, &g)
+		m.Groups = append(m.Groups, g)
+	case strings.EqualFold(kind, KindCampaign):
+		var c CampaignSpec
+		err = decodeStrict(data, &c)
+		m.Campaigns = append(m.Campaigns, c)
+	case kind == "":
+		return fmt.Errorf("no kind given, expected one of %s", strings.Join(kinds, ", "))
+	default:
+		return fmt.Errorf("unknown kind %q, expected one of %s", kind, strings.Join(kinds, ", "))
+	}
+	return err
+}
+
+// decodeStrict decodes JSON, failing on unknown fields to catch typos.
+func decodeStrict(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// readPassword reads a sending profile's password from the environment
+// variable or file its password_env or password_file field names, removing
+// those fields. It returns "" if neither is given.
+func readPassword(fields map[string]interface{}) (string, error) {
+	var given []string
+	for _, key := range []string{"password", "password_env", "password_file"} {
+		if value, ok := fields[key]; ok && value != nil && value != "" {
+			given = append(given, key)
+		}
+	}
+	if len(given) > 1 {
+		return "", fmt.Errorf("only one of %s can be given", strings.Join(given, ", "))
+	}
+
+	env, envOK := fields["password_env"].(string)
+	file, fileOK := fields["password_file"].(string)
+	switch {
+	case fields["password_env"] != nil && !envOK:
+		return "", fmt.Errorf("password_env: expected a string, got %T", fields["password_env"])
+	case fields["password_file"] != nil && !fileOK:
+		return "", fmt.Errorf("password_file: expected a string, got %T", fields["password_file"])
+	}
+	delete(fields, "password_env")
+	delete(fields, "password_file")
+
+	switch {
+	case env != "":
+		password, ok := os.LookupEnv(env)
+		if !ok {
+			return "", fmt.Errorf("password_env: $%s isn't set", env)
+		}
+		return password, nil
+	case file != "":
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("password_file: %v", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	return "", nil
+}
+
+// JSONValue converts a value decoded from YAML to one JSON can encode, i.e.
+// its maps, which YAML decodes with keys of any type, to maps with string
+// keys. Slices are converted in place.
+func JSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = JSONValue(value)
+		}
+		return m
+	case []interface{}:
+		for i := range v {
+			v[i] = JSONValue(v[i])
+		}
+	}
+	return v
+}
+
+// Validate checks every resource is valid and that names are unique per
+// kind. References between resources are checked when planning, as they may
+// refer to resources that already exist.
+func (m *Manifest) Validate() error {
+	seen := make(map[string]bool)
+	unique := func(kind, name string) error {
+		key := kind + "\x00" + name
+		if seen[key] {
+			return fmt.Errorf("manifest: %s %q is given more than once", kind, name)
+		}
+		seen[key] = true
+		return nil
+	}
+
+	for i := range m.SendingProfiles {
+		sp := &m.SendingProfiles[i]
+		if err := sp.Validate(); err != nil {
+			return err
+		}
+		if err := unique(KindSendingProfile, sp.Name); err != nil {
+			return err
+		}
+	}
+	for i := range m.Templates {
+		t := &m.Templates[i]
+		if err := t.Validate(); err != nil {
+			return err
+		}
+		if err := unique(KindTemplate, t.Name); err != nil {
+			return err
+		}
+	}
+	for i := range m.LandingPages {
+		p := &m.LandingPages[i]
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if err := unique(KindLandingPage, p.Name); err != nil {
+			return err
+		}
+	}
+	for i := range m.Groups {
+		g := &m.Groups[i]
+		if err := g.Validate(); err != nil {
+			return err
+		}
+		if err := unique(KindGroup, g.Name); err != nil {
+			return err
+		}
+	}
+	for i := range m.Campaigns {
+		c := &m.Campaigns[i]
+		if err := c.Campaign().Validate(); err != nil {
+			return err
+		}
+		if err := unique(KindCampaign, c.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// has reports whether the manifest has any resources of the given kind.
+func (m *Manifest) has(kind string) bool {
+	switch kind {
+	case KindSendingProfile:
+		return len(m.SendingProfiles) > 0
+	case KindTemplate:
+		return len(m.Templates) > 0
+	case KindLandingPage:
+		return len(m.LandingPages) > 0
+	case KindGroup:
+		return len(m.Groups) > 0
+	case KindCampaign:
+		return len(m.Campaigns) > 0
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package manifest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testManifest = `kind: SendingProfile
+name: Corp SMTP
+host: smtp.example.com
+from_address: IT <it@example.com>
+---
+kind: template
+name: Invoice
+subject: Your invoice is overdue
+html: <p>Hi {{.FirstName}}</p>
+---
+kind: LandingPage
+name: O365 login
+html: <form></form>
+capture_credentials: true
+---
+kind: Group
+name: Finance
+targets:
+  - email: jdoe@example.com
+    first_name: John
+---
+kind: Campaign
+name: Q4 invoices
+template: Invoice
+page: O365 login
+smtp: Corp SMTP
+groups: [Finance]
+url: https://phish.example.com
+---
+`
+
+func TestParse(t *testing.T) {
+	m, err := Parse(strings.NewReader(testManifest), "test.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.SendingProfiles) != 1 || len(m.Templates) != 1 || len(m.LandingPages) != 1 ||
+		len(m.Groups) != 1 || len(m.Campaigns) != 1 {
+		t.Fatalf("got %+v, want one resource of each kind", m)
+	}
+	if got := m.Groups[0].Targets[0].FirstName; got != "John" {
+		t.Errorf("got first name %q, want John", got)
+	}
+	if c := m.Campaigns[0]; c.SMTP != "Corp SMTP" || len(c.Groups) != 1 || c.Groups[0] != "Finance" {
+		t.Errorf("got campaign %+v", c)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"no kind":      "name: Invoice\n",
+		"unknown kind": "kind: Webhook\nname: Hook\n",
+		"not a map":    "- kind: Template\n",
+		"typo":         "kind: Template\nname: Invoice\nsubjcet: Hi\nhtml: Hi\n",
+		"invalid":      "kind: Group\nname: Empty\n",
+		"duplicate": "kind: Template\nname: Invoice\nhtml: Hi\n---\n" +
+			"kind: Template\nname: Invoice\nhtml: Hello\n",
+	}
+	for name, manifest := range tests {
+		if _, err := Parse(strings.NewReader(manifest), "test.yaml"); err == nil {
+			t.Errorf("%s: got no error", name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"b.yaml":          "kind: Template\nname: B\nhtml: B\n",
+		"a/a.yml":         "kind: Template\nname: A\nhtml: A\n",
+		"c.json":          `{"kind": "Template", "name": "C", "html": "C"}`,
+		"README.md":       "Not a manifest",
+		"d.yaml.disabled": "kind: Template\nname: D\nhtml: D\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, tmpl := range m.Templates {
+		names = append(names, tmpl.Name)
+	}
+	if got := strings.Join(names, ","); got != "A,B,C" {
+		t.Errorf("got templates %s, want A, B and C", got)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing")); err == nil {
+		t.Error("loading a missing file: got no error")
+	}
+}
+
+func TestSendingProfilePassword(t *testing.T) {
+	file, err := ioutil.TempFile("", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.WriteString("from a file\n"); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	os.Setenv("MANIFEST_TEST_PASSWORD", "from the environment")
+	defer os.Unsetenv("MANIFEST_TEST_PASSWORD")
+	os.Unsetenv("MANIFEST_TEST_UNSET")
+
+	const profile = "kind: SendingProfile\nname: Corp SMTP\nhost: smtp.example.com\nfrom_address: it@example.com\n"
+	tests := []struct {
+		name     string
+		fields   string
+		password string
+		fails    bool
+	}{
+		{"none", "", "", false},
+		{"password", "password: hunter2\n", "hunter2", false},
+		{"env", "password_env: MANIFEST_TEST_PASSWORD\n", "from the environment", false},
+		{"file", "password_file: " + file.Name() + "\n", "from a file", false},
+		{"unset env", "password_env: MANIFEST_TEST_UNSET\n", "", true},
+		{"missing file", "password_file: " + file.Name() + ".missing\n", "", true},
+		{"not a string", "password_env: [A, B]\n", "", true},
+		{"both", "password: hunter2\npassword_env: MANIFEST_TEST_PASSWORD\n", "", true},
+	}
+	for _, tt := range tests {
+		m, err := Parse(strings.NewReader(profile+tt.fields), "test.yaml")
+		if tt.fails {
+			if err == nil {
+				t.Errorf("%s: got no error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := m.SendingProfiles[0].Password; got != tt.password {
+			t.Errorf("%s: got password %q, want %q", tt.name, got, tt.password)
+		}
+	}
+}
+
+func TestJSONValue(t *testing.T) {
+	v := JSONValue(map[interface{}]interface{}{
+		"name": "Finance",
+		1:      true,
+		"targets": []interface{}{
+			map[interface{}]interface{}{"email": "jdoe@example.com"},
+		},
+	})
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		t.Fatalf("got %T, want a map with string keys", v)
+	}
+	if m["name"] != "Finance" || m["1"] != true {
+		t.Errorf("got %v", m)
+	}
+	targets, _ := m["targets"].([]interface{})
+	if len(targets) != 1 {
+		t.Fatalf("got targets %v", m["targets"])
+	}
+	if _, ok := targets[0].(map[string]interface{}); !ok {
+		t.Errorf("got target %T, want a map with string keys", targets[0])
+	}
+}
@@ -0,0 +1,425 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ttacon/gophish"
+)
+
+// Action is what a plan does to a resource.
+type Action string
+
+// The actions of a plan.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	ActionNone   Action = "none"
+)
+
+// Change is what a plan does to one resource.
+type Change struct {
+	Kind   string
+	Name   string
+	Action Action
+
+	// ID is the ID of the existing resource, if there is one.
+	ID int `json:",omitempty"`
+
+	// Details describe the change, i.e. which fields an update changes.
+	Details []string `json:",omitempty"`
+
+	// desired is the resource to create or update.
+	desired interface{}
+}
+
+// Plan is what it takes to make an instance match a manifest.
+type Plan struct {
+	Changes []Change
+
+	// Warnings are about differences the plan can't reconcile, i.e.
+	// campaigns that differ from their manifest, as gophish can't change
+	// campaigns once they're created.
+	Warnings []string
+}
+
+// PlanOptions configures NewPlan.
+type PlanOptions struct {
+	// Prune deletes resources that aren't in the manifest. Only kinds the
+	// manifest has any resources of are pruned, and campaigns never are, as
+	// deleting a campaign deletes its results.
+	Prune bool
+}
+
+// resource is a resource of any kind, for reconciling them generically.
+type resource struct {
+	name  string
+	id    int
+	value interface{}
+}
+
+// NewPlan compares the manifest with the instance the client is for, and
+// plans the changes that make the instance match it.
+func NewPlan(ctx context.Context, client *gophish.Client, m *Manifest, opts PlanOptions) (*Plan, error) {
+	p := &Plan{}
+
+	profiles, err := client.SendingProfiles.ListSendingProfilesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var have, want []resource
+	passwords := make(map[string]string, len(profiles))
+	for _, sp := range profiles {
+		sp := normalizeSendingProfile(sp)
+		have = append(have, resource{sp.Name, sp.ID, sp})
+		passwords[sp.Name] = sp.Password
+	}
+	for _, sp := range m.SendingProfiles {
+		sp := normalizeSendingProfile(sp)
+		// Passwords are best kept out of manifests, so a profile without
+		// one keeps the password it has.
+		if sp.Password == "" {
+			sp.Password = passwords[sp.Name]
+		}
+		want = append(want, resource{sp.Name, 0, sp})
+	}
+	p.reconcile(KindSendingProfile, have, want, opts.Prune && m.has(KindSendingProfile))
+
+	templates, err := client.Templates.ListTemplatesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	have, want = nil, nil
+	for _, t := range templates {
+		have = append(have, resource{t.Name, t.ID, t})
+	}
+	for _, t := range m.Templates {
+		want = append(want, resource{t.Name, 0, t})
+	}
+	p.reconcile(KindTemplate, have, want, opts.Prune && m.has(KindTemplate))
+
+	pages, err := client.LandingPages.ListLandingPagesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	have, want = nil, nil
+	for _, lp := range pages {
+		lp := normalizeLandingPage(lp)
+		have = append(have, resource{lp.Name, lp.ID, lp})
+	}
+	for _, lp := range m.LandingPages {
+		lp := normalizeLandingPage(lp)
+		want = append(want, resource{lp.Name, 0, lp})
+	}
+	p.reconcile(KindLandingPage, have, want, opts.Prune && m.has(KindLandingPage))
+
+	groups, err := client.Groups.ListGroupsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	have, want = nil, nil
+	for _, g := range groups {
+		have = append(have, resource{g.Name, g.ID, g})
+	}
+	for _, g := range m.Groups {
+		want = append(want, resource{g.Name, 0, g})
+	}
+	p.reconcile(KindGroup, have, want, opts.Prune && m.has(KindGroup))
+
+	campaigns, err := client.Campaigns.ListCampaignsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.planCampaigns(m, campaigns, profiles, templates, pages, groups); err != nil {
+		return nil, err
+	}
+
+	// Delete in the reverse order of creating, so nothing is deleted
+	// before what uses it.
+	sort.SliceStable(p.Changes, func(i, j int) bool {
+		a, b := p.Changes[i], p.Changes[j]
+		if (a.Action == ActionDelete) != (b.Action == ActionDelete) {
+			return b.Action == ActionDelete
+		}
+		if a.Action == ActionDelete {
+			return kindOrder(a.Kind) > kindOrder(b.Kind)
+		}
+		return false
+	})
+	return p, nil
+}
+
+func kindOrder(kind string) int {
+	for i, k := range kinds {
+		if k == kind {
+			return i
+		}
+	}
+	return len(kinds)
+}
+
+// reconcile plans the changes to make the existing resources of a kind match
+// the desired ones.
+func (p *Plan) reconcile(kind string, existing, desired []resource, prune bool) {
+	byName := make(map[string]resource, len(existing))
+	for _, r := range existing {
+		byName[r.name] = r
+	}
+
+	wanted := make(map[string]bool, len(desired))
+	for _, r := range desired {
+		wanted[r.name] = true
+		have, ok := byName[r.name]
+		if !ok {
+			p.Changes = append(p.Changes, Change{
+				Kind:    kind,
+				Name:    r.name,
+				Action:  ActionCreate,
+				desired: r.value,
+			})
+			continue
+		}
+
+		change := Change{
+			Kind:    kind,
+			Name:    r.name,
+			Action:  ActionNone,
+			ID:      have.id,
+			Details: fieldChanges(have.value, r.value),
+			desired: r.value,
+		}
+		if len(change.Details) > 0 {
+			change.Action = ActionUpdate
+		}
+		p.Changes = append(p.Changes, change)
+	}
+
+	if !prune {
+		return
+	}
+	for _, r := range existing {
+		if !wanted[r.name] {
+			p.Changes = append(p.Changes, Change{
+				Kind:   kind,
+				Name:   r.name,
+				Action: ActionDelete,
+				ID:     r.id,
+			})
+		}
+	}
+}
+
+// planCampaigns plans creating the campaigns that don't exist, checking
+// they only refer to resources that exist or will be created.
+func (p *Plan) planCampaigns(
+	m *Manifest,
+	campaigns []gophish.Campaign,
+	profiles []gophish.SendingProfile,
+	templates []gophish.Template,
+	pages []gophish.LandingPage,
+	groups []gophish.Group,
+) error {
+	names := make(map[string]bool)
+	known := func(kind, name string) bool {
+		return names[kind+"\x00"+name]
+	}
+	add := func(kind, name string) {
+		names[kind+"\x00"+name] = true
+	}
+	for _, sp := range profiles {
+		add(KindSendingProfile, sp.Name)
+	}
+	for _, t := range templates {
+		add(KindTemplate, t.Name)
+	}
+	for _, lp := range pages {
+		add(KindLandingPage, lp.Name)
+	}
+	for _, g := range groups {
+		add(KindGroup, g.Name)
+	}
+	for _, c := range p.Changes {
+		add(c.Kind, c.Name)
+	}
+
+	existing := make(map[string]gophish.Campaign, len(campaigns))
+	for _, c := range campaigns {
+		existing[c.Name] = c
+	}
+
+	for i := range m.Campaigns {
+		spec := &m.Campaigns[i]
+		refs := []struct{ kind, name string }{
+			{KindTemplate, spec.Template},
+			{KindLandingPage, spec.Page},
+			{KindSendingProfile, spec.SMTP},
+		}
+		for _, g := range spec.Groups {
+			refs = append(refs, struct{ kind, name string }{KindGroup, g})
+		}
+		for _, ref := range refs {
+			if !known(ref.kind, ref.name) {
+				return fmt.Errorf("manifest: campaign %q refers to %s %q, which doesn't exist", spec.Name, ref.kind, ref.name)
+			}
+		}
+
+		c, ok := existing[spec.Name]
+		if !ok {
+			p.Changes = append(p.Changes, Change{
+				Kind:    KindCampaign,
+				Name:    spec.Name,
+				Action:  ActionCreate,
+				desired: spec,
+			})
+			continue
+		}
+		p.Changes = append(p.Changes, Change{
+			Kind:   KindCampaign,
+			Name:   spec.Name,
+			Action: ActionNone,
+			ID:     c.ID,
+		})
+		if details := campaignChanges(&c, spec); len(details) > 0 {
+			p.Warnings = append(p.Warnings, fmt.Sprintf(
+				"campaign %q differs from its manifest (%s), but gophish can't change campaigns",
+				spec.Name, strings.Join(details, ", "),
+			))
+		}
+	}
+	return nil
+}
+
+func campaignChanges(c *gophish.Campaign, spec *CampaignSpec) []string {
+	var details []string
+	compare := func(field, have, want string) {
+		if have != want {
+			details = append(details, fmt.Sprintf("%s: %q -> %q", field, have, want))
+		}
+	}
+	compare("template", c.Template.Name, spec.Template)
+	compare("page", c.Page.Name, spec.Page)
+	compare("smtp", c.SMTP.Name, spec.SMTP)
+	compare("url", c.URL, spec.URL)
+
+	var groups []string
+	for _, g := range c.Groups {
+		groups = append(groups, g.Name)
+	}
+	want := append([]string(nil), spec.Groups...)
+	sort.Strings(groups)
+	sort.Strings(want)
+	compare("groups", strings.Join(groups, ", "), strings.Join(want, ", "))
+	return details
+}
+
+// normalizeSendingProfile fills in the defaults gophish does, so they don't
+// show up as differences.
+func normalizeSendingProfile(sp gophish.SendingProfile) gophish.SendingProfile {
+	if sp.InterfaceType == "" {
+		sp.InterfaceType = "SMTP"
+	}
+	if _, _, err := net.SplitHostPort(sp.Host); err != nil && sp.Host != "" {
+		sp.Host += ":25"
+	}
+	return sp
+}
+
+func normalizeLandingPage(lp gophish.LandingPage) gophish.LandingPage {
+	// gophish never captures passwords without capturing credentials.
+	if !lp.CaptureCredentials {
+		lp.CapturePasswords = false
+	}
+	return lp
+}
+
+var targetsType = reflect.TypeOf([]gophish.Target(nil))
+
+// fieldChanges describes how the fields of two resources of the same type
+// differ, ignoring their IDs and modification dates. Secrets and long text
+// aren't included.
+func fieldChanges(have, want interface{}) []string {
+	hv, wv := reflect.ValueOf(have), reflect.ValueOf(want)
+	typ := hv.Type()
+
+	var changes []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Name == "ID" || field.Name == "ModifiedDate" {
+			continue
+		}
+		a, b := hv.Field(i), wv.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+
+		switch {
+		case field.Type == targetsType:
+			diff := gophish.DiffTargets(a.Interface().([]gophish.Target), b.Interface().([]gophish.Target))
+			if !diff.Empty() {
+				lines := strings.Split(strings.TrimRight(diff.String(), "\n"), "\n")
+				changes = append(changes, lines...)
+			}
+		case a.Kind() == reflect.Slice:
+			if (a.Len() > 0 || b.Len() > 0) && !reflect.DeepEqual(a.Interface(), b.Interface()) {
+				changes = append(changes, name+": changed")
+			}
+		case reflect.DeepEqual(a.Interface(), b.Interface()):
+		case a.Kind() == reflect.String && (name == "password" || isLong(a.String()) || isLong(b.String())):
+			changes = append(changes, name+": changed")
+		case a.Kind() == reflect.String:
+			changes = append(changes, fmt.Sprintf("%s: %q -> %q", name, a.String(), b.String()))
+		default:
+			changes = append(changes, fmt.Sprintf("%s: %v -> %v", name, a.Interface(), b.Interface()))
+		}
+	}
+	return changes
+}
+
+func isLong(s string) bool {
+	return len(s) > 60 || strings.Contains(s, "\n")
+}
+
+// String describes the plan for people: a line per change, prefixed with
+// "+", "~" or "-" for creates, updates and deletes, followed by the details
+// of the change, any warnings and a summary.
+func (p *Plan) String() string {
+	var b strings.Builder
+	var create, update, del, none int
+	for _, c := range p.Changes {
+		switch c.Action {
+		case ActionCreate:
+			create++
+			fmt.Fprintf(&b, "+ create %s %q\n", c.Kind, c.Name)
+		case ActionUpdate:
+			update++
+			fmt.Fprintf(&b, "~ update %s %q\n", c.Kind, c.Name)
+		case ActionDelete:
+			del++
+			fmt.Fprintf(&b, "- delete %s %q\n", c.Kind, c.Name)
+		default:
+			none++
+			continue
+		}
+		for _, d := range c.Details {
+			fmt.Fprintf(&b, "    %s\n", d)
+		}
+	}
+	for _, w := range p.Warnings {
+		fmt.Fprintf(&b, "! %s\n", w)
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete, %d unchanged.\n", create, update, del, none)
+	return b.String()
+}
+
+// Empty reports whether the plan changes nothing.
+func (p *Plan) Empty() bool {
+	for _, c := range p.Changes {
+		if c.Action != ActionNone {
+			return false
+		}
+	}
+	return true
+}
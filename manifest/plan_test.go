@@ -0,0 +1,238 @@
+package manifest
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/gophishtest"
+)
+
+// plan parses the manifest and plans it against the client's instance.
+func plan(t *testing.T, client *gophish.Client, manifest string, opts PlanOptions) *Plan {
+	t.Helper()
+	m, err := Parse(strings.NewReader(manifest), "test.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewPlan(context.Background(), client, m, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// actions summarizes the changes of a plan, i.e. "create Template Invoice".
+func actions(p *Plan) []string {
+	var actions []string
+	for _, c := range p.Changes {
+		actions = append(actions, string(c.Action)+" "+c.Kind+" "+c.Name)
+	}
+	return actions
+}
+
+func TestPlanCreate(t *testing.T) {
+	srv := gophishtest.NewServer("token")
+	defer srv.Close()
+	client := srv.Client()
+
+	p := plan(t, client, testManifest, PlanOptions{})
+	want := []string{
+		"create SendingProfile Corp SMTP",
+		"create Template Invoice",
+		"create LandingPage O365 login",
+		"create Group Finance",
+		"create Campaign Q4 invoices",
+	}
+	if got := actions(p); !reflect.DeepEqual(got, want) {
+		t.Errorf("got changes %q, want %q", got, want)
+	}
+	if !strings.HasSuffix(p.String(), "Plan: 5 to create, 0 to update, 0 to delete, 0 unchanged.\n") {
+		t.Errorf("got plan:\n%s", p)
+	}
+
+	if err := p.Apply(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+	campaigns, err := client.Campaigns.ListCampaigns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(campaigns) != 1 || campaigns[0].SMTP.Name != "Corp SMTP" {
+		t.Errorf("got campaigns %+v, want the campaign created", campaigns)
+	}
+
+	// gophish's defaults, i.e. the SMTP port, don't show up as changes.
+	if p := plan(t, client, testManifest, PlanOptions{}); !p.Empty() {
+		t.Errorf("got plan after applying it:\n%s", p)
+	}
+}
+
+func TestPlanUpdate(t *testing.T) {
+	srv := gophishtest.NewServer("token")
+	defer srv.Close()
+	client := srv.Client()
+	if err := plan(t, client, testManifest, PlanOptions{}).Apply(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+
+	updated := strings.NewReplacer(
+		"subject: Your invoice is overdue", "subject: Final notice",
+		"first_name: John", "first_name: Johnny",
+		"url: https://phish.example.com", "url: https://phish.example.org",
+	).Replace(testManifest)
+	p := plan(t, client, updated, PlanOptions{})
+
+	var details []string
+	for _, c := range p.Changes {
+		if c.Action == ActionUpdate {
+			details = append(details, c.Kind+": "+strings.Join(c.Details, "; "))
+		}
+	}
+	want := []string{
+		`Template: subject: "Your invoice is overdue" -> "Final notice"`,
+		"Group: ~ jdoe@example.com (John) -> jdoe@example.com (Johnny); " +
+			"0 to add, 0 to remove, 1 to change, 0 unchanged",
+	}
+	if !reflect.DeepEqual(details, want) {
+		t.Errorf("got updates %q, want %q", details, want)
+	}
+	if len(p.Warnings) != 1 || !strings.Contains(p.Warnings[0], `url: "https://phish.example.com" -> "https://phish.example.org"`) {
+		t.Errorf("got warnings %q, want one about the campaign's URL", p.Warnings)
+	}
+
+	if err := p.Apply(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+	templates, err := client.Templates.ListTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if templates[0].Subject != "Final notice" {
+		t.Errorf("got subject %q after applying", templates[0].Subject)
+	}
+}
+
+func TestPlanKeepsPasswords(t *testing.T) {
+	srv := gophishtest.NewServer("token")
+	defer srv.Close()
+	client := srv.Client()
+
+	created, err := client.SendingProfiles.CreateSendingProfile(&gophish.SendingProfile{
+		Name:        "Corp SMTP",
+		Host:        "smtp.example.com",
+		FromAddress: "IT <it@example.com>",
+		Password:    "hunter2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const profile = "kind: SendingProfile\nname: Corp SMTP\nhost: smtp.example.com\nfrom_address: IT <it@example.com>\n"
+	if p := plan(t, client, profile, PlanOptions{}); !p.Empty() {
+		t.Errorf("got plan for a profile without a password:\n%s", p)
+	}
+
+	// Other changes keep the password too.
+	p := plan(t, client, strings.Replace(profile, "IT <", "Helpdesk <", 1), PlanOptions{})
+	if got := actions(p); len(got) != 1 || got[0] != "update SendingProfile Corp SMTP" {
+		t.Fatalf("got changes %q, want the profile updated", got)
+	}
+	if err := p.Apply(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+	sp, err := client.SendingProfiles.GetSendingProfile(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sp.FromAddress != "Helpdesk <it@example.com>" || sp.Password != "hunter2" {
+		t.Errorf("got from address %q and password %q after updating", sp.FromAddress, sp.Password)
+	}
+
+	p = plan(t, client, profile+"password: correct horse\n", PlanOptions{})
+	if got := p.Changes[0].Details; !reflect.DeepEqual(got, []string{
+		"password: changed",
+		`from_address: "Helpdesk <it@example.com>" -> "IT <it@example.com>"`,
+	}) {
+		t.Errorf("got details %q, want the new password hidden", got)
+	}
+}
+
+func TestPlanPrune(t *testing.T) {
+	srv := gophishtest.NewServer("token")
+	defer srv.Close()
+	client := srv.Client()
+	if err := plan(t, client, testManifest, PlanOptions{}).Apply(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only templates and groups are in the manifest, so nothing else is
+	// pruned, and deletes come last, in the reverse order of creates.
+	const manifest = "kind: Group\nname: Staff\ntargets: [{email: jdoe@example.com}]\n---\n" +
+		"kind: Template\nname: Welcome\nhtml: Hi\n"
+	p := plan(t, client, manifest, PlanOptions{Prune: true})
+	want := []string{
+		"create Template Welcome",
+		"create Group Staff",
+		"delete Group Finance",
+		"delete Template Invoice",
+	}
+	if got := actions(p); !reflect.DeepEqual(got, want) {
+		t.Errorf("got changes %q, want %q", got, want)
+	}
+
+	// Without pruning, nothing is deleted.
+	p = plan(t, client, manifest, PlanOptions{})
+	if got := actions(p); !reflect.DeepEqual(got, want[:2]) {
+		t.Errorf("got changes %q without pruning, want %q", got, want[:2])
+	}
+
+	// Campaigns are never pruned.
+	p = plan(t, client, testManifest+"kind: Campaign\nname: Other\ntemplate: Invoice\npage: O365 login\n"+
+		"smtp: Corp SMTP\ngroups: [Finance]\nurl: https://phish.example.com\n", PlanOptions{Prune: true})
+	for _, c := range p.Changes {
+		if c.Action == ActionDelete {
+			t.Errorf("got %s %s %q, want nothing deleted", c.Action, c.Kind, c.Name)
+		}
+	}
+}
+
+func TestPlanCampaignReferences(t *testing.T) {
+	srv := gophishtest.NewServer("token")
+	defer srv.Close()
+	client := srv.Client()
+
+	// Campaigns can refer to resources that exist but aren't in the
+	// manifest.
+	if _, err := client.Groups.CreateGroup(&gophish.Group{
+		Name:    "Staff",
+		Targets: []gophish.Target{{Email: "jdoe@example.com"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	manifest := strings.Replace(testManifest, "groups: [Finance]", "groups: [Finance, Staff]", 1)
+	p := plan(t, client, manifest, PlanOptions{})
+	if err := p.Apply(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, missing := range []struct{ old, new string }{
+		{"template: Invoice", "template: Missing"},
+		{"page: O365 login", "page: Missing"},
+		{"smtp: Corp SMTP", "smtp: Missing"},
+		{"groups: [Finance]", "groups: [Finance, Missing]"},
+	} {
+		manifest := strings.Replace(testManifest, missing.old, missing.new, 1)
+		manifest = strings.Replace(manifest, "name: Q4 invoices", "name: Q1 invoices", 1)
+		m, err := Parse(strings.NewReader(manifest), "test.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = NewPlan(context.Background(), client, m, PlanOptions{})
+		if err == nil || !strings.Contains(err.Error(), `"Missing", which doesn't exist`) {
+			t.Errorf("%s: got error %v, want the missing reference", missing.new, err)
+		}
+	}
+}
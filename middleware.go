@@ -0,0 +1,147 @@
+package gophish
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"time"
+)
+
+// Middleware wraps the http.RoundTripper used to issue every request made by
+// a client's services, i.e. to add headers, log or trace requests.
+// Middleware runs for every attempt at a request, after the Authorization
+// header has been set, so it can also be used to implement custom
+// authentication.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware adds the given middleware to the client. Middleware is run
+// in the order it's given, the first one seeing the request first and the
+// response last.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(s *Service) error {
+		s.Middleware = append(s.Middleware, mw...)
+		return nil
+	}
+}
+
+// BeforeRequest creates middleware that calls fn with every request before
+// it is sent. If fn returns an error, the request is aborted with it.
+func BeforeRequest(fn func(*http.Request) error) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := fn(req); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// AfterResponse creates middleware that calls fn with every request and its
+// outcome, once a response (or an error) is received.
+func AfterResponse(fn func(*http.Request, *http.Response, error)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			fn(req, resp, err)
+			return resp, err
+		})
+	}
+}
+
+// RequestTiming is how long a single request took, as reported by
+// TimingMiddleware.
+type RequestTiming struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// TimingMiddleware creates middleware that reports how long each request
+// took to receive a response.
+func TimingMiddleware(fn func(RequestTiming)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+
+			timing := RequestTiming{
+				Method:   req.Method,
+				Path:     req.URL.Path,
+				Duration: time.Since(start),
+				Err:      err,
+			}
+			if resp != nil {
+				timing.StatusCode = resp.StatusCode
+			}
+			fn(timing)
+			return resp, err
+		})
+	}
+}
+
+// DebugMiddleware creates middleware that dumps every request and response,
+// including their bodies, to w. The Authorization header and any api_key
+// query parameter are redacted so that API tokens don't end up in logs.
+func DebugMiddleware(w io.Writer) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if dump, err := dumpRequest(req); err != nil {
+				fmt.Fprintf(w, "gophish: failed to dump request: %v\n", err)
+			} else {
+				fmt.Fprintf(w, "%s\n", dump)
+			}
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				fmt.Fprintf(w, "gophish: %s %s: %v\n\n", req.Method, req.URL, err)
+				return nil, err
+			}
+
+			if dump, err := httputil.DumpResponse(resp, true); err != nil {
+				fmt.Fprintf(w, "gophish: failed to dump response: %v\n", err)
+			} else {
+				fmt.Fprintf(w, "%s\n\n", dump)
+			}
+			return resp, nil
+		})
+	}
+}
+
+// dumpRequest dumps a copy of the request with its API token redacted,
+// leaving the original request (and its body) intact.
+func dumpRequest(req *http.Request) ([]byte, error) {
+	dup := req.Clone(req.Context())
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		dup.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	if dup.Header.Get("Authorization") != "" {
+		dup.Header.Set("Authorization", "REDACTED")
+	}
+	if query := dup.URL.Query(); query.Get("api_key") != "" {
+		query.Set("api_key", "REDACTED")
+		u := *dup.URL
+		u.RawQuery = query.Encode()
+		dup.URL = &u
+	}
+	return httputil.DumpRequestOut(dup, true)
+}
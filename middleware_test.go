@@ -0,0 +1,175 @@
+package gophish_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ttacon/gophish"
+)
+
+func TestDebugMiddlewareRedactsToken(t *testing.T) {
+	srv, _ := newTestServer(t)
+	defer srv.Close()
+
+	var log bytes.Buffer
+	client := srv.Client(gophish.WithMiddleware(
+		// gophish also takes the token as a query parameter.
+		gophish.BeforeRequest(func(req *http.Request) error {
+			query := req.URL.Query()
+			query.Set("api_key", "token")
+			req.URL.RawQuery = query.Encode()
+			return nil
+		}),
+		gophish.DebugMiddleware(&log),
+	))
+
+	created, err := client.Templates.CreateTemplate(&gophish.Template{
+		Name: "Invoice",
+		HTML: "<p>Your invoice is overdue</p>",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The body was dumped, and still reached the server.
+	if created.HTML != "<p>Your invoice is overdue</p>" {
+		t.Errorf("got HTML %q, want the body sent intact", created.HTML)
+	}
+
+	dump := log.String()
+	if strings.Contains(dump, "token") {
+		t.Errorf("the token is in the dump:\n%s", dump)
+	}
+	for _, want := range []string{
+		"POST /api/templates?api_key=REDACTED",
+		"Authorization: REDACTED",
+		"Your invoice is overdue",
+		"HTTP/1.1 201 Created",
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("the dump doesn't contain %q:\n%s", want, dump)
+		}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	srv, _ := newTestServer(t)
+	defer srv.Close()
+
+	var calls []string
+	trace := func(name string) gophish.Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return gophish.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" request")
+				resp, err := next.RoundTrip(req)
+				calls = append(calls, name+" response")
+				return resp, err
+			})
+		}
+	}
+	client := srv.Client(
+		gophish.WithMiddleware(trace("first"), trace("second")),
+		gophish.WithMiddleware(trace("third")),
+	)
+	if _, err := client.Templates.ListTemplates(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"first request",
+		"second request",
+		"third request",
+		"third response",
+		"second response",
+		"first response",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("got calls %q, want %q", calls, want)
+	}
+}
+
+func TestBeforeRequestAborts(t *testing.T) {
+	srv, _ := newTestServer(t)
+	defer srv.Close()
+
+	errNope := errors.New("nope")
+	sent := false
+	client := srv.Client(gophish.WithMiddleware(
+		gophish.BeforeRequest(func(*http.Request) error {
+			return errNope
+		}),
+		gophish.BeforeRequest(func(*http.Request) error {
+			sent = true
+			return nil
+		}),
+	))
+
+	_, err := client.Templates.CreateTemplate(&gophish.Template{Name: "Invoice", HTML: "Hi"})
+	if !errors.Is(err, errNope) {
+		t.Errorf("got error %v, want the middleware's", err)
+	}
+	if sent {
+		t.Error("the request was sent")
+	}
+
+	templates, err := srv.Client().Templates.ListTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 0 {
+		t.Errorf("got %d templates, want the request not to have reached the server", len(templates))
+	}
+}
+
+func TestAfterResponse(t *testing.T) {
+	srv, _ := newTestServer(t)
+	defer srv.Close()
+
+	var statuses []int
+	client := srv.Client(gophish.WithMiddleware(
+		gophish.AfterResponse(func(req *http.Request, resp *http.Response, err error) {
+			if err != nil {
+				t.Errorf("%s %s: got error %v", req.Method, req.URL.Path, err)
+				return
+			}
+			statuses = append(statuses, resp.StatusCode)
+		}),
+	))
+
+	if _, err := client.Templates.ListTemplates(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Templates.GetTemplate(42); !gophish.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+	if want := []int{http.StatusOK, http.StatusNotFound}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("got statuses %v, want %v", statuses, want)
+	}
+}
+
+func TestTimingMiddleware(t *testing.T) {
+	srv, _ := newTestServer(t)
+	defer srv.Close()
+
+	var timings []gophish.RequestTiming
+	client := srv.Client(gophish.WithMiddleware(
+		gophish.TimingMiddleware(func(timing gophish.RequestTiming) {
+			timings = append(timings, timing)
+		}),
+	))
+	if _, err := client.Templates.ListTemplates(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(timings) != 1 {
+		t.Fatalf("got %d timings, want 1", len(timings))
+	}
+	timing := timings[0]
+	if timing.Method != http.MethodGet || timing.Path != "/api/templates" ||
+		timing.StatusCode != http.StatusOK || timing.Err != nil || timing.Duration <= 0 {
+		t.Errorf("got timing %+v", timing)
+	}
+}
@@ -0,0 +1,378 @@
+// Package migrate copies resources from one gophish instance to another.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/backup"
+	"github.com/ttacon/gophish/manifest"
+)
+
+// DefaultKinds are the kinds of resources copied unless Options.Kinds says
+// otherwise. Campaigns have to be asked for, as copying a campaign
+// schedules it on the destination.
+var DefaultKinds = []string{
+	manifest.KindSendingProfile,
+	manifest.KindTemplate,
+	manifest.KindLandingPage,
+	manifest.KindGroup,
+}
+
+// PasswordFunc returns the password to give a sending profile on the
+// destination, i.e. by prompting for it.
+type PasswordFunc func(sp gophish.SendingProfile) (string, error)
+
+// Options configures Migrate.
+type Options struct {
+	// Kinds are the kinds of resources to copy (see the manifest
+	// package's Kind constants), DefaultKinds if empty.
+	Kinds []string
+
+	// Names, if set, limits the copy to resources with these names.
+	Names []string
+
+	// OnConflict is what to do with resources whose names are taken on
+	// the destination. It defaults to backup.Skip, reporting them as
+	// conflicted. Campaigns are never overwritten.
+	OnConflict backup.CollisionPolicy
+
+	// Password gives sending profiles their passwords on the destination.
+	// It's only called for the profiles that are created or overwritten,
+	// as they're saved. If it's nil, or for dry runs, passwords aren't
+	// copied: created profiles have none, and overwritten ones keep the
+	// password they have.
+	Password PasswordFunc
+
+	// DryRun works out what would be copied without changing anything.
+	DryRun bool
+}
+
+// Status is what happened to a resource.
+type Status string
+
+// The statuses of resources in a report.
+const (
+	Copied     Status = "copied"
+	Skipped    Status = "skipped"
+	Conflicted Status = "conflicted"
+)
+
+// Entry is what happened to one resource.
+type Entry struct {
+	Kind string
+	Name string
+
+	// NewName is the name it was copied as, if it was renamed.
+	NewName string `json:",omitempty"`
+
+	Status Status
+	Reason string `json:",omitempty"`
+}
+
+// Report is the outcome of Migrate.
+type Report struct {
+	Entries []Entry
+}
+
+func (r *Report) add(e Entry) {
+	r.Entries = append(r.Entries, e)
+}
+
+// String describes the report for people, a line per resource.
+func (r *Report) String() string {
+	var b strings.Builder
+	counts := make(map[Status]int)
+	for _, e := range r.Entries {
+		counts[e.Status]++
+		fmt.Fprintf(&b, "%-10s %s %q", e.Status, e.Kind, e.Name)
+		if e.NewName != "" {
+			fmt.Fprintf(&b, " as %q", e.NewName)
+		}
+		if e.Reason != "" {
+			fmt.Fprintf(&b, " (%s)", e.Reason)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%d copied, %d skipped, %d conflicted.\n", counts[Copied], counts[Skipped], counts[Conflicted])
+	return b.String()
+}
+
+// Migrate copies the selected resources from one instance to another.
+// Campaigns are recreated referring to the template, landing page, sending
+// profile and groups by their names on the destination, following any
+// renames. Only campaigns that haven't launched yet are copied, as results
+// can't be.
+func Migrate(ctx context.Context, from, to *gophish.Client, opts Options) (*Report, error) {
+	kinds := opts.Kinds
+	if len(kinds) == 0 {
+		kinds = DefaultKinds
+	}
+	policy := opts.OnConflict
+	if policy == "" {
+		policy = backup.Skip
+	}
+
+	source, err := backup.ExportKinds(ctx, from, kinds...)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: reading source: %v", err)
+	}
+	sel := selection{kinds: kinds, names: opts.Names}
+	selected := sel.filter(source)
+
+	report := &Report{}
+	copyPasswords := opts.Password != nil && !opts.DryRun
+	redacted := make(map[string]bool)
+	if !copyPasswords {
+		for _, sp := range selected.SendingProfiles {
+			redacted[sp.Name] = sp.Password != ""
+		}
+	}
+	password := func(sp gophish.SendingProfile, existing *gophish.SendingProfile) (string, error) {
+		switch {
+		case copyPasswords:
+			return opts.Password(sp)
+		case existing != nil:
+			return existing.Password, nil
+		}
+		return "", nil
+	}
+
+	// Campaigns are copied separately, as backup doesn't restore them.
+	campaigns := selected.Campaigns
+	selected.Campaigns = nil
+	imported, err := backup.Import(ctx, to, selected, backup.ImportOptions{
+		OnCollision: policy,
+		DryRun:      opts.DryRun,
+		Password:    password,
+	})
+	if imported != nil {
+		for _, res := range imported.Resources {
+			e := Entry{Kind: res.Kind, Name: res.Name, NewName: res.NewName, Status: Copied}
+			switch res.Outcome {
+			case backup.OutcomeSkipped:
+				e.Status = Conflicted
+				e.Reason = "already exists on the destination"
+			case backup.OutcomeOverwritten:
+				e.Reason = "overwrote the existing one"
+			}
+			if e.Status == Copied && res.Kind == manifest.KindSendingProfile && redacted[res.Name] {
+				if res.Outcome == backup.OutcomeOverwritten {
+					e.Reason += ", kept its password"
+				} else {
+					e.Reason = "password not copied"
+				}
+			}
+			report.add(e)
+		}
+	}
+	if err != nil {
+		return report, err
+	}
+
+	if len(campaigns) > 0 {
+		if err := copyCampaigns(ctx, to, campaigns, imported, policy, opts.DryRun, report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// copyCampaigns recreates campaigns on the destination, rewriting their
+// references to follow renames.
+func copyCampaigns(
+	ctx context.Context,
+	to *gophish.Client,
+	campaigns []gophish.Campaign,
+	imported *backup.ImportResult,
+	policy backup.CollisionPolicy,
+	dryRun bool,
+	report *Report,
+) error {
+	dest, err := destinationNames(ctx, to)
+	if err != nil {
+		return err
+	}
+	renamed := make(map[string]string)
+	for _, res := range imported.Resources {
+		name := res.Name
+		if res.NewName != "" {
+			renamed[res.Kind+"\x00"+res.Name] = res.NewName
+			name = res.NewName
+		}
+		dest[res.Kind+"\x00"+name] = true
+	}
+	rename := func(kind, name string) (string, error) {
+		if newName, ok := renamed[kind+"\x00"+name]; ok {
+			name = newName
+		}
+		if !dest[kind+"\x00"+name] {
+			return "", fmt.Errorf("%s %q doesn't exist on the destination", kind, name)
+		}
+		return name, nil
+	}
+
+	now := time.Now()
+	for _, c := range campaigns {
+		e := Entry{Kind: manifest.KindCampaign, Name: c.Name, Status: Copied}
+		if !c.LaunchDate.After(now) {
+			e.Status = Skipped
+			e.Reason = "already launched, results can't be copied"
+			report.add(e)
+			continue
+		}
+
+		spec := manifest.CampaignSpec{
+			Name:       c.Name,
+			URL:        c.URL,
+			LaunchDate: c.LaunchDate,
+			SendByDate: c.SendByDate,
+		}
+		var err error
+		if spec.Template, err = rename(manifest.KindTemplate, c.Template.Name); err == nil {
+			if spec.Page, err = rename(manifest.KindLandingPage, c.Page.Name); err == nil {
+				spec.SMTP, err = rename(manifest.KindSendingProfile, c.SMTP.Name)
+			}
+		}
+		for _, g := range c.Groups {
+			if err != nil {
+				break
+			}
+			var name string
+			if name, err = rename(manifest.KindGroup, g.Name); err == nil {
+				spec.Groups = append(spec.Groups, name)
+			}
+		}
+		if err != nil {
+			e.Status = Skipped
+			e.Reason = err.Error()
+			report.add(e)
+			continue
+		}
+
+		if dest[manifest.KindCampaign+"\x00"+c.Name] {
+			if policy != backup.Rename {
+				e.Status = Conflicted
+				e.Reason = "already exists on the destination"
+				report.add(e)
+				continue
+			}
+			for i := 2; dest[manifest.KindCampaign+"\x00"+spec.Name]; i++ {
+				spec.Name = fmt.Sprintf("%s (%d)", c.Name, i)
+			}
+			e.NewName = spec.Name
+		}
+
+		if !dryRun {
+			if _, err := to.Campaigns.CreateCampaignContext(ctx, spec.Campaign()); err != nil {
+				return fmt.Errorf("migrate: copying campaign %q: %v", c.Name, err)
+			}
+		}
+		dest[manifest.KindCampaign+"\x00"+spec.Name] = true
+		report.add(e)
+	}
+	return nil
+}
+
+// destinationNames returns the kinds and names of the resources on the
+// destination, as kind+"\x00"+name.
+func destinationNames(ctx context.Context, to *gophish.Client) (map[string]bool, error) {
+	names := make(map[string]bool)
+	profiles, err := to.SendingProfiles.ListSendingProfilesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, sp := range profiles {
+		names[manifest.KindSendingProfile+"\x00"+sp.Name] = true
+	}
+	templates, err := to.Templates.ListTemplatesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		names[manifest.KindTemplate+"\x00"+t.Name] = true
+	}
+	pages, err := to.LandingPages.ListLandingPagesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, lp := range pages {
+		names[manifest.KindLandingPage+"\x00"+lp.Name] = true
+	}
+	groups, err := to.Groups.ListGroupSummariesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		names[manifest.KindGroup+"\x00"+g.Name] = true
+	}
+	campaigns, err := to.Campaigns.ListCampaignsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range campaigns {
+		names[manifest.KindCampaign+"\x00"+c.Name] = true
+	}
+	return names, nil
+}
+
+// selection is the resources Options selects.
+type selection struct {
+	kinds []string
+	names []string
+}
+
+func (s selection) has(kind, name string) bool {
+	found := false
+	for _, k := range s.kinds {
+		if strings.EqualFold(k, kind) {
+			found = true
+		}
+	}
+	if !found {
+		return false
+	}
+	if len(s.names) == 0 {
+		return true
+	}
+	for _, n := range s.names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// filter returns the selected resources of an archive.
+func (s selection) filter(a *backup.Archive) *backup.Archive {
+	selected := &backup.Archive{Version: a.Version, Created: a.Created}
+	for _, sp := range a.SendingProfiles {
+		if s.has(manifest.KindSendingProfile, sp.Name) {
+			selected.SendingProfiles = append(selected.SendingProfiles, sp)
+		}
+	}
+	for _, t := range a.Templates {
+		if s.has(manifest.KindTemplate, t.Name) {
+			selected.Templates = append(selected.Templates, t)
+		}
+	}
+	for _, lp := range a.LandingPages {
+		if s.has(manifest.KindLandingPage, lp.Name) {
+			selected.LandingPages = append(selected.LandingPages, lp)
+		}
+	}
+	for _, g := range a.Groups {
+		if s.has(manifest.KindGroup, g.Name) {
+			selected.Groups = append(selected.Groups, g)
+		}
+	}
+	for _, c := range a.Campaigns {
+		if s.has(manifest.KindCampaign, c.Name) {
+			selected.Campaigns = append(selected.Campaigns, c)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,172 @@
+package migrate
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ttacon/gophish"
+	"github.com/ttacon/gophish/backup"
+	"github.com/ttacon/gophish/gophishtest"
+	"github.com/ttacon/gophish/manifest"
+)
+
+func createProfile(t *testing.T, client *gophish.Client, name, password string) *gophish.SendingProfile {
+	t.Helper()
+	sp, err := client.SendingProfiles.CreateSendingProfile(&gophish.SendingProfile{
+		Name:        name,
+		Host:        "smtp.example.com",
+		FromAddress: "it@example.com",
+		Password:    password,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sp
+}
+
+// passwords returns the passwords of the client's sending profiles by name.
+func passwords(t *testing.T, client *gophish.Client) map[string]string {
+	t.Helper()
+	profiles, err := client.SendingProfiles.ListSendingProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	passwords := make(map[string]string)
+	for _, sp := range profiles {
+		passwords[sp.Name] = sp.Password
+	}
+	return passwords
+}
+
+func TestMigrateOverwriteKeepsPasswords(t *testing.T) {
+	src := gophishtest.NewServer("token")
+	defer src.Close()
+	dst := gophishtest.NewServer("token")
+	defer dst.Close()
+	from, to := src.Client(), dst.Client()
+
+	createProfile(t, from, "Corp SMTP", "source")
+	createProfile(t, from, "Relay", "source")
+	createProfile(t, to, "Corp SMTP", "destination")
+
+	report, err := Migrate(context.Background(), from, to, Options{
+		Kinds:      []string{manifest.KindSendingProfile},
+		OnConflict: backup.Overwrite,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{
+		{Kind: manifest.KindSendingProfile, Name: "Corp SMTP", Status: Copied, Reason: "overwrote the existing one, kept its password"},
+		{Kind: manifest.KindSendingProfile, Name: "Relay", Status: Copied, Reason: "password not copied"},
+	}
+	if !reflect.DeepEqual(report.Entries, want) {
+		t.Errorf("got %+v, want %+v", report.Entries, want)
+	}
+	if got, want := passwords(t, to), map[string]string{"Corp SMTP": "destination", "Relay": ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got passwords %v, want %v", got, want)
+	}
+}
+
+func TestMigratePromptsForSavedProfiles(t *testing.T) {
+	src := gophishtest.NewServer("token")
+	defer src.Close()
+	dst := gophishtest.NewServer("token")
+	defer dst.Close()
+	from, to := src.Client(), dst.Client()
+
+	for _, name := range []string{"Corp SMTP", "Relay", "Spare"} {
+		createProfile(t, from, name, "source")
+	}
+	createProfile(t, to, "Corp SMTP", "destination")
+	createProfile(t, to, "Spare", "destination")
+
+	var prompted []string
+	password := func(sp gophish.SendingProfile) (string, error) {
+		prompted = append(prompted, sp.Name)
+		return "prompted", nil
+	}
+
+	// Dry runs don't prompt.
+	if _, err := Migrate(context.Background(), from, to, Options{
+		OnConflict: backup.Rename,
+		Password:   password,
+		DryRun:     true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(prompted) != 0 {
+		t.Errorf("prompted for %q on a dry run", prompted)
+	}
+
+	// Only the profiles that are saved are prompted for: not the skipped
+	// ones, and under the names they're saved as.
+	report, err := Migrate(context.Background(), from, to, Options{
+		Names:      []string{"Corp SMTP", "Relay"},
+		OnConflict: backup.Rename,
+		Password:   password,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Corp SMTP (2)", "Relay"}; !reflect.DeepEqual(prompted, want) {
+		t.Errorf("prompted for %q, want %q", prompted, want)
+	}
+	for _, e := range report.Entries {
+		if e.Reason != "" {
+			t.Errorf("%s %q: got reason %q, want none", e.Kind, e.Name, e.Reason)
+		}
+	}
+	want := map[string]string{
+		"Corp SMTP":     "destination",
+		"Corp SMTP (2)": "prompted",
+		"Relay":         "prompted",
+		"Spare":         "destination",
+	}
+	if got := passwords(t, to); !reflect.DeepEqual(got, want) {
+		t.Errorf("got passwords %v, want %v", got, want)
+	}
+
+	prompted = nil
+	if _, err := Migrate(context.Background(), from, to, Options{
+		Kinds:    []string{manifest.KindSendingProfile},
+		Password: password,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(prompted) != 0 {
+		t.Errorf("prompted for %q, which already exist and are skipped", prompted)
+	}
+}
+
+func TestMigrateOnlyReadsSelectedKinds(t *testing.T) {
+	src := gophishtest.NewServer("token")
+	defer src.Close()
+	dst := gophishtest.NewServer("token")
+	defer dst.Close()
+
+	var requests []string
+	from := src.Client(gophish.WithMiddleware(gophish.BeforeRequest(func(req *http.Request) error {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		return nil
+	})))
+	if _, err := from.Templates.CreateTemplate(&gophish.Template{Name: "Invoice", HTML: "Hi"}); err != nil {
+		t.Fatal(err)
+	}
+	requests = nil
+
+	report, err := Migrate(context.Background(), from, dst.Client(), Options{
+		Kinds: []string{manifest.KindTemplate},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Entries) != 1 || report.Entries[0].Status != Copied {
+		t.Errorf("got %+v, want the template copied", report.Entries)
+	}
+	if want := []string{"GET /api/templates"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("got requests %q to the source, want %q", requests, want)
+	}
+}
@@ -0,0 +1,186 @@
+package gophish
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures the Service shared by every resource service of a
+// Client.
+type Option func(*Service) error
+
+// WithHTTPClient makes the client issue requests using the given
+// *http.Client instead of http.DefaultClient. Options that modify the client
+// (WithTimeout) or its transport (i.e. WithTLSConfig) work on a copy of it,
+// so they must be given after this option: given before, they're silently
+// lost when it replaces the client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *Service) error {
+		if client == nil {
+			return errors.New("gophish: nil http client")
+		}
+		s.HTTPClient = client
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to gophish, i.e.
+// to trust an internal certificate authority.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(s *Service) error {
+		t, err := s.ownTransport()
+		if err != nil {
+			return err
+		}
+		t.TLSClientConfig = cfg
+		return nil
+	}
+}
+
+// WithRootCAs makes the client trust certificates signed by the given pool
+// of certificate authorities.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(s *Service) error {
+		t, err := s.ownTransport()
+		if err != nil {
+			return err
+		}
+		t.TLSClientConfig = cloneTLSConfig(t.TLSClientConfig)
+		t.TLSClientConfig.RootCAs = pool
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables verification of the certificate presented
+// by gophish. This is mostly useful for the self-signed certificate gophish
+// generates for its admin server, and shouldn't be used otherwise.
+func WithInsecureSkipVerify() Option {
+	return func(s *Service) error {
+		t, err := s.ownTransport()
+		if err != nil {
+			return err
+		}
+		t.TLSClientConfig = cloneTLSConfig(t.TLSClientConfig)
+		t.TLSClientConfig.InsecureSkipVerify = true
+		return nil
+	}
+}
+
+// WithProxy routes every request through the proxy at the given URL.
+func WithProxy(proxyURL string) Option {
+	return func(s *Service) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("gophish: invalid proxy URL: %v", err)
+		}
+		t, err := s.ownTransport()
+		if err != nil {
+			return err
+		}
+		t.Proxy = http.ProxyURL(u)
+		return nil
+	}
+}
+
+// WithTimeout sets an overall time limit for each request, including reading
+// the response body. Like the transport options, it must be given after any
+// WithHTTPClient.
+func WithTimeout(d time.Duration) Option {
+	return func(s *Service) error {
+		s.ownClient().Timeout = d
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(s *Service) error {
+		s.UserAgent = ua
+		return nil
+	}
+}
+
+// WithBaseURL overrides the host given to New/NewClient. The URL must be an
+// absolute http or https URL, optionally with a path prefix if gophish is
+// served under one.
+func WithBaseURL(baseURL string) Option {
+	return func(s *Service) error {
+		if _, err := parseBaseURL(baseURL); err != nil {
+			return err
+		}
+		s.Host = baseURL
+		return nil
+	}
+}
+
+// parseBaseURL validates the given gophish base URL, returning it without
+// any trailing slash so that API paths can be appended to it.
+func parseBaseURL(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("gophish: invalid base URL: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf(
+			"gophish: invalid base URL %q: scheme must be http or https",
+			baseURL,
+		)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("gophish: invalid base URL %q: missing host", baseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf(
+			"gophish: invalid base URL %q: must not have a query or fragment",
+			baseURL,
+		)
+	}
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// ownClient replaces the service's *http.Client with a copy that can be
+// safely modified without affecting http.DefaultClient or a client given to
+// WithHTTPClient.
+func (s *Service) ownClient() *http.Client {
+	var client http.Client
+	if s.HTTPClient != nil {
+		client = *s.HTTPClient
+	}
+	s.HTTPClient = &client
+	return &client
+}
+
+// ownTransport is like ownClient, but for the client's *http.Transport.
+func (s *Service) ownTransport() (*http.Transport, error) {
+	client := s.ownClient()
+
+	var base *http.Transport
+	switch t := client.Transport.(type) {
+	case nil:
+		base = http.DefaultTransport.(*http.Transport)
+	case *http.Transport:
+		base = t
+	default:
+		return nil, fmt.Errorf(
+			"gophish: cannot configure transport of type %T",
+			client.Transport,
+		)
+	}
+
+	t := base.Clone()
+	client.Transport = t
+	return t, nil
+}
+
+func cloneTLSConfig(cfg *tls.Config) *tls.Config {
+	if cfg == nil {
+		return &tls.Config{}
+	}
+	return cfg.Clone()
+}
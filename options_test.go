@@ -0,0 +1,52 @@
+package gophish
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithHTTPClientOrder(t *testing.T) {
+	given := &http.Client{Timeout: time.Minute}
+
+	s, err := newService("https://gophish:3333", "token", []Option{
+		WithHTTPClient(given),
+		WithTimeout(time.Second),
+		WithInsecureSkipVerify(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.HTTPClient == given {
+		t.Fatal("options modified the given client rather than a copy")
+	}
+	if s.HTTPClient.Timeout != time.Second {
+		t.Errorf("got timeout %v, want 1s", s.HTTPClient.Timeout)
+	}
+	transport, ok := s.HTTPClient.Transport.(*http.Transport)
+	if !ok || !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("got a transport that verifies certificates")
+	}
+	if given.Timeout != time.Minute || given.Transport != nil {
+		t.Errorf("the given client was modified: %+v", given)
+	}
+
+	// Given before WithHTTPClient, the options are lost, as documented.
+	s, err = newService("https://gophish:3333", "token", []Option{
+		WithTimeout(time.Second),
+		WithInsecureSkipVerify(),
+		WithHTTPClient(given),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.HTTPClient != given {
+		t.Error("WithHTTPClient didn't replace the client")
+	}
+}
+
+func TestWithHTTPClientNil(t *testing.T) {
+	if _, err := New("https://gophish:3333", "token", WithHTTPClient(nil)); err == nil {
+		t.Error("got no error for a nil client")
+	}
+}
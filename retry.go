@@ -0,0 +1,162 @@
+package gophish
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how requests that fail with a network error, a 5xx or
+// a 429 status code are retried. The zero value disables retries.
+//
+// Only idempotent requests (GET, PUT and DELETE) are retried by default,
+// since retrying a POST (i.e. CreateCampaign) may create a resource twice if
+// gophish had already handled the original request.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of times a request is retried after
+	// the initial attempt.
+	MaxRetries int
+
+	// MinBackoff is the delay before the first retry, it doubles with each
+	// subsequent retry up to MaxBackoff. A random jitter of up to half the
+	// delay is subtracted so that concurrent clients don't retry in lockstep.
+	// A Retry-After header sent by gophish (or a proxy in front of it) takes
+	// precedence, but is also capped to MaxBackoff if that is set.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// RetryNonIdempotent opts POST requests into being retried as well.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is a reasonable policy for gophish installations behind
+// a flaky proxy or load balancer.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: 500 * time.Millisecond,
+	MaxBackoff: 30 * time.Second,
+}
+
+// WithRetryPolicy makes the client retry failed requests according to the
+// given policy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(s *Service) error {
+		s.RetryPolicy = p
+		return nil
+	}
+}
+
+// shouldRetry reports whether a request should be retried given the outcome
+// of its attempt'th try (starting at 0).
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, resp *http.Response, err error) bool {
+	if attempt >= p.MaxRetries {
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(method) {
+		return false
+	}
+	if err != nil {
+		// Errors caused by our own context aren't worth retrying.
+		return ctx.Err() == nil
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= 500
+}
+
+// backoff returns how long to wait before retrying after the attempt'th try,
+// honoring any Retry-After header in the response up to MaxBackoff.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && d > p.MaxBackoff {
+				d = p.MaxBackoff
+			}
+			return d
+		}
+	}
+
+	d := p.MinBackoff
+	for i := 0; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d - jitter(d/2)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	d := time.Until(t)
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
+
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// jitter returns a random duration in [0, max).
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return time.Duration(jitterRand.Int63n(int64(max)))
+}
+
+// sleep waits for d, returning early with the context's error if it's done
+// first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// discard drains and closes a response body so that the underlying
+// connection can be reused.
+func discard(resp *http.Response) {
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+	resp.Body.Close()
+}
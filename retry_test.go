@@ -0,0 +1,238 @@
+package gophish
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first failures requests with the given status code,
+// counting every request it receives.
+func flakyServer(failures int32, status int, header http.Header) (*httptest.Server, *int32) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	return srv, &attempts
+}
+
+var fastRetries = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: time.Millisecond,
+	MaxBackoff: 5 * time.Millisecond,
+}
+
+func TestRetryIdempotent(t *testing.T) {
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		srv, attempts := flakyServer(2, http.StatusServiceUnavailable, nil)
+
+		s, err := newService(srv.URL, "token", []Option{WithRetryPolicy(fastRetries)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := s.MakeRequest(method, "/api/templates/", nil)
+		if err != nil {
+			t.Errorf("%s: %v", method, err)
+		} else {
+			resp.Body.Close()
+		}
+		if atomic.LoadInt32(attempts) != 3 {
+			t.Errorf("%s: got %d attempts, want 3", method, atomic.LoadInt32(attempts))
+		}
+		srv.Close()
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	srv, attempts := flakyServer(10, http.StatusBadGateway, nil)
+	defer srv.Close()
+
+	s, err := newService(srv.URL, "token", []Option{WithRetryPolicy(fastRetries)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.MakeRequest(http.MethodGet, "/api/templates/", nil)
+	if !hasStatus(err, http.StatusBadGateway) {
+		t.Errorf("got error %v, want a 502 APIError", err)
+	}
+	if atomic.LoadInt32(attempts) != 4 {
+		t.Errorf("got %d attempts, want 4", atomic.LoadInt32(attempts))
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	tests := []struct {
+		name     string
+		optIn    bool
+		attempts int32
+	}{
+		{"default", false, 1},
+		{"opted in", true, 2},
+	}
+	for _, tt := range tests {
+		srv, attempts := flakyServer(1, http.StatusServiceUnavailable, nil)
+
+		policy := fastRetries
+		policy.RetryNonIdempotent = tt.optIn
+		s, err := newService(srv.URL, "token", []Option{WithRetryPolicy(policy)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := s.MakeRequest(http.MethodPost, "/api/campaigns/", Campaign{})
+		if err == nil {
+			resp.Body.Close()
+		}
+		if atomic.LoadInt32(attempts) != tt.attempts {
+			t.Errorf("%s: got %d attempts, want %d", tt.name, atomic.LoadInt32(attempts), tt.attempts)
+		}
+		if tt.optIn && err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if !tt.optIn && !hasStatus(err, http.StatusServiceUnavailable) {
+			t.Errorf("%s: got error %v, want a 503 APIError", tt.name, err)
+		}
+		srv.Close()
+	}
+}
+
+func TestRetryOnlyRetriableStatuses(t *testing.T) {
+	srv, attempts := flakyServer(1, http.StatusNotFound, nil)
+	defer srv.Close()
+
+	s, err := newService(srv.URL, "token", []Option{WithRetryPolicy(fastRetries)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.MakeRequest(http.MethodGet, "/api/templates/1", nil)
+	if !IsNotFound(err) {
+		t.Errorf("got error %v, want a 404 APIError", err)
+	}
+	if atomic.LoadInt32(attempts) != 1 {
+		t.Errorf("got %d attempts, want 1", atomic.LoadInt32(attempts))
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	header := func(v string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": {v}}}
+	}
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		resp   *http.Response
+		min    time.Duration
+		max    time.Duration
+	}{
+		{
+			name:   "seconds",
+			policy: RetryPolicy{MinBackoff: time.Millisecond},
+			resp:   header("7"),
+			min:    7 * time.Second,
+			max:    7 * time.Second,
+		},
+		{
+			name:   "capped to MaxBackoff",
+			policy: RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Second},
+			resp:   header("3600"),
+			min:    2 * time.Second,
+			max:    2 * time.Second,
+		},
+		{
+			name:   "http date",
+			policy: RetryPolicy{MinBackoff: time.Millisecond},
+			resp:   header(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)),
+			min:    58 * time.Minute,
+			max:    time.Hour,
+		},
+		{
+			name:   "invalid falls back to backoff",
+			policy: RetryPolicy{MinBackoff: time.Second},
+			resp:   header("soon"),
+			min:    500 * time.Millisecond,
+			max:    time.Second,
+		},
+		{
+			name:   "no response",
+			policy: RetryPolicy{MinBackoff: time.Second, MaxBackoff: 3 * time.Second},
+			resp:   nil,
+			min:    1500 * time.Millisecond,
+			max:    3 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		attempt := 0
+		if tt.resp == nil {
+			attempt = 5
+		}
+		d := tt.policy.backoff(attempt, tt.resp)
+		if d < tt.min || d > tt.max {
+			t.Errorf("%s: got backoff %v, want within [%v, %v]", tt.name, d, tt.min, tt.max)
+		}
+	}
+}
+
+func TestRetryAfterIsHonored(t *testing.T) {
+	srv, attempts := flakyServer(1, http.StatusTooManyRequests, http.Header{
+		"Retry-After": {"1"},
+	})
+	defer srv.Close()
+
+	policy := fastRetries
+	policy.MaxBackoff = 0
+	s, err := newService(srv.URL, "token", []Option{WithRetryPolicy(policy)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	resp, err := s.MakeRequest(http.MethodGet, "/api/templates/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least 1s", elapsed)
+	}
+	if atomic.LoadInt32(attempts) != 2 {
+		t.Errorf("got %d attempts, want 2", atomic.LoadInt32(attempts))
+	}
+}
+
+func TestRetryContextCancelledDuringSleep(t *testing.T) {
+	srv, attempts := flakyServer(10, http.StatusServiceUnavailable, http.Header{
+		"Retry-After": {"60"},
+	})
+	defer srv.Close()
+
+	s, err := newService(srv.URL, "token", []Option{WithRetryPolicy(RetryPolicy{
+		MaxRetries: 3,
+		MinBackoff: time.Millisecond,
+	})})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = s.MakeRequestContext(ctx, http.MethodGet, "/api/templates/", nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("returned after %v, want the sleep to be interrupted", elapsed)
+	}
+	if atomic.LoadInt32(attempts) != 1 {
+		t.Errorf("got %d attempts, want 1", atomic.LoadInt32(attempts))
+	}
+}
@@ -0,0 +1,278 @@
+// Package roster parses recipient lists (i.e. CSV and XLSX exports from an
+// HR system) into gophish targets, validating them locally before they're
+// pushed to gophish.
+package roster
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/ttacon/gophish"
+)
+
+// Field is a target field that a column can be mapped to.
+type Field string
+
+// The fields of a gophish.Target.
+const (
+	FieldEmail     Field = "email"
+	FieldFirstName Field = "first_name"
+	FieldLastName  Field = "last_name"
+	FieldPosition  Field = "position"
+)
+
+// defaultColumns are the column headers recognized without any mapping,
+// matching the headers gophish's own CSV import expects.
+var defaultColumns = map[string]Field{
+	"email":      FieldEmail,
+	"first name": FieldFirstName,
+	"last name":  FieldLastName,
+	"position":   FieldPosition,
+}
+
+// Options configures how a roster is parsed.
+type Options struct {
+	// Columns maps column headers (compared case insensitively) to target
+	// fields, i.e. {"Mail": FieldEmail, "Title": FieldPosition}. Columns
+	// named "Email", "First Name", "Last Name" and "Position" are mapped
+	// without having to be listed.
+	Columns map[string]Field
+
+	// Sheet is the name of the worksheet to read from XLSX files, the first
+	// worksheet is read if it's empty.
+	Sheet string
+
+	// TitleCaseNames normalizes first and last names to title case, i.e.
+	// "jANE" becomes "Jane". Email addresses are always lowercased.
+	TitleCaseNames bool
+}
+
+// ParseColumnMapping parses column mappings of the form "Header=field" (i.e.
+// "Mail=email"), where field is one of email, first_name, last_name or
+// position.
+func ParseColumnMapping(mappings []string) (map[string]Field, error) {
+	columns := make(map[string]Field, len(mappings))
+	for _, m := range mappings {
+		parts := strings.SplitN(m, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("roster: invalid column mapping %q", m)
+		}
+		field := Field(strings.ToLower(strings.TrimSpace(parts[1])))
+		switch field {
+		case FieldEmail, FieldFirstName, FieldLastName, FieldPosition:
+		default:
+			return nil, fmt.Errorf("roster: unknown field %q in column mapping %q", field, m)
+		}
+		columns[strings.TrimSpace(parts[0])] = field
+	}
+	return columns, nil
+}
+
+// RowError is a problem with a single row of a roster.
+type RowError struct {
+	// Row is the 1-based row number in the file, the header being row 1.
+	// Blank lines in CSV files aren't counted.
+	Row    int
+	Column string
+	Value  string
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+	}
+	return fmt.Sprintf("row %d: %s %q: %v", e.Row, e.Column, e.Value, e.Err)
+}
+
+// Result is a parsed roster. Rows with errors are left out of Targets.
+type Result struct {
+	Targets []gophish.Target
+	Errors  []*RowError
+}
+
+// Err returns an error summarizing the row errors, or nil if there are none.
+func (r *Result) Err() error {
+	switch len(r.Errors) {
+	case 0:
+		return nil
+	case 1:
+		return r.Errors[0]
+	}
+	return fmt.Errorf("%v (and %d more errors)", r.Errors[0], len(r.Errors)-1)
+}
+
+// Group returns a group with the given name made up of the roster's targets,
+// ready to be passed to GroupsService.CreateGroup.
+func (r *Result) Group(name string) *gophish.Group {
+	targets := make([]gophish.Target, len(r.Targets))
+	copy(targets, r.Targets)
+	return &gophish.Group{
+		Name:    name,
+		Targets: targets,
+	}
+}
+
+// ParseFile parses a CSV or XLSX roster, based on the file's extension.
+func ParseFile(path string, opts Options) (*Result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv", ".txt":
+		return ParseCSV(f, opts)
+	case ".xlsx":
+		info, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		return ParseXLSX(f, info.Size(), opts)
+	}
+	return nil, fmt.Errorf("roster: unsupported file type %q", filepath.Ext(path))
+}
+
+// ParseCSV parses a CSV roster with a header row.
+func ParseCSV(r io.Reader, opts Options) (*Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("roster: %v", err)
+	}
+	return parseRows(rows, opts)
+}
+
+// parseRows turns the rows of a roster, the first being the header, into
+// targets.
+func parseRows(rows [][]string, opts Options) (*Result, error) {
+	if len(rows) == 0 {
+		return nil, errors.New("roster: missing header row")
+	}
+
+	columns := make(map[string]Field, len(defaultColumns)+len(opts.Columns))
+	for header, field := range defaultColumns {
+		columns[header] = field
+	}
+	for header, field := range opts.Columns {
+		columns[strings.ToLower(strings.TrimSpace(header))] = field
+	}
+
+	header := rows[0]
+	indices := make(map[Field]int)
+	for i, name := range header {
+		field, ok := columns[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			continue
+		}
+		if _, dup := indices[field]; dup {
+			return nil, fmt.Errorf("roster: more than one column maps to %s", field)
+		}
+		indices[field] = i
+	}
+	if _, ok := indices[FieldEmail]; !ok {
+		return nil, errors.New("roster: no column maps to email")
+	}
+
+	result := &Result{}
+	seen := make(map[string]int)
+	for n, row := range rows[1:] {
+		rowNum := n + 2
+		if isBlank(row) {
+			continue
+		}
+
+		value := func(field Field) string {
+			i, ok := indices[field]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return strings.Join(strings.Fields(row[i]), " ")
+		}
+
+		target := gophish.Target{
+			Email:     value(FieldEmail),
+			FirstName: value(FieldFirstName),
+			LastName:  value(FieldLastName),
+			Position:  value(FieldPosition),
+		}
+		if opts.TitleCaseNames {
+			target.FirstName = titleCase(target.FirstName)
+			target.LastName = titleCase(target.LastName)
+		}
+
+		emailColumn := header[indices[FieldEmail]]
+		email, err := normalizeEmail(target.Email)
+		if err != nil {
+			result.Errors = append(result.Errors, &RowError{
+				Row:    rowNum,
+				Column: emailColumn,
+				Value:  target.Email,
+				Err:    err,
+			})
+			continue
+		}
+		if first, dup := seen[email]; dup {
+			result.Errors = append(result.Errors, &RowError{
+				Row:    rowNum,
+				Column: emailColumn,
+				Value:  target.Email,
+				Err:    fmt.Errorf("duplicate of row %d", first),
+			})
+			continue
+		}
+		seen[email] = rowNum
+		target.Email = email
+
+		result.Targets = append(result.Targets, target)
+	}
+	return result, nil
+}
+
+// normalizeEmail validates an email address, returning it lowercased and
+// without any display name.
+func normalizeEmail(email string) (string, error) {
+	if email == "" {
+		return "", errors.New("missing email address")
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return "", errors.New("invalid email address")
+	}
+	return strings.ToLower(addr.Address), nil
+}
+
+func isBlank(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// titleCase uppercases the first letter of every word (including the parts
+// of hyphenated names) and lowercases the rest.
+func titleCase(s string) string {
+	runes := []rune(strings.ToLower(s))
+	start := true
+	for i, r := range runes {
+		if start && unicode.IsLetter(r) {
+			runes[i] = unicode.ToUpper(r)
+		}
+		start = r == ' ' || r == '-'
+	}
+	return string(runes)
+}
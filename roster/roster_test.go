@@ -0,0 +1,155 @@
+package roster
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ttacon/gophish"
+)
+
+func TestParseCSVDefaultColumns(t *testing.T) {
+	result, err := ParseCSV(strings.NewReader(
+		"First Name,Last Name,Email,Position\n"+
+			"John,Doe,jdoe@example.com,Analyst\n",
+	), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []gophish.Target{
+		{Email: "jdoe@example.com", FirstName: "John", LastName: "Doe", Position: "Analyst"},
+	}
+	if !reflect.DeepEqual(result.Targets, want) {
+		t.Errorf("got %+v, want %+v", result.Targets, want)
+	}
+	if err := result.Err(); err != nil {
+		t.Errorf("got error %v", err)
+	}
+}
+
+func TestParseCSVColumnMapping(t *testing.T) {
+	columns, err := ParseColumnMapping([]string{
+		"mail=email",
+		" Given Name = first_name",
+		"SURNAME=Last_Name",
+		"Title=position",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ParseCSV(strings.NewReader(
+		"Mail,Given Name,Surname,Title,Department\n"+
+			`John Doe <JDoe@Example.com>,  jOHN ,o'neil-smith,Senior   Analyst,Finance`+"\n",
+	), Options{Columns: columns, TitleCaseNames: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []gophish.Target{{
+		Email:     "jdoe@example.com",
+		FirstName: "John",
+		LastName:  "O'neil-Smith",
+		Position:  "Senior Analyst",
+	}}
+	if !reflect.DeepEqual(result.Targets, want) {
+		t.Errorf("got %+v, want %+v", result.Targets, want)
+	}
+}
+
+func TestParseColumnMappingErrors(t *testing.T) {
+	for _, m := range []string{"Mail", "=email", "Mail=phone"} {
+		if _, err := ParseColumnMapping([]string{m}); err == nil {
+			t.Errorf("%q: got no error", m)
+		}
+	}
+}
+
+func TestParseCSVRowErrors(t *testing.T) {
+	result, err := ParseCSV(strings.NewReader(
+		"Email,First Name\n"+ // row 1
+			"jdoe@example.com,John\n"+ // row 2
+			"not an email,Nope\n"+ // row 3
+			",Missing\n"+ // row 4
+			",\n"+ // row 5, blank
+			"JDOE@example.com,Duplicate\n"+ // row 6
+			"asmith@example.com,Alice\n", // row 7
+	), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var emails []string
+	for _, target := range result.Targets {
+		emails = append(emails, target.Email)
+	}
+	if got := strings.Join(emails, ","); got != "jdoe@example.com,asmith@example.com" {
+		t.Errorf("got targets %s, want the valid rows", got)
+	}
+
+	want := []string{
+		`row 3: Email "not an email": invalid email address`,
+		`row 4: Email "": missing email address`,
+		`row 6: Email "JDOE@example.com": duplicate of row 2`,
+	}
+	var got []string
+	for _, rowErr := range result.Errors {
+		got = append(got, rowErr.Error())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got errors %q, want %q", got, want)
+	}
+	if err := result.Err(); err == nil || err.Error() != want[0]+" (and 2 more errors)" {
+		t.Errorf("got summary %v", err)
+	}
+}
+
+func TestParseCSVHeaderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+		opts Options
+	}{
+		{"empty", "", Options{}},
+		{"no email column", "Name,Position\nJohn,Analyst\n", Options{}},
+		{
+			"two email columns",
+			"Email,Mail\njdoe@example.com,jdoe@example.com\n",
+			Options{Columns: map[string]Field{"Mail": FieldEmail}},
+		},
+	}
+	for _, tt := range tests {
+		if _, err := ParseCSV(strings.NewReader(tt.csv), tt.opts); err == nil {
+			t.Errorf("%s: got no error", tt.name)
+		}
+	}
+}
+
+func TestResultGroup(t *testing.T) {
+	result := &Result{Targets: []gophish.Target{{Email: "jdoe@example.com"}}}
+	group := result.Group("Staff")
+	if group.Name != "Staff" || !reflect.DeepEqual(group.Targets, result.Targets) {
+		t.Errorf("got %+v", group)
+	}
+
+	// The group has its own copy of the targets.
+	group.Targets[0].Email = "changed@example.com"
+	if result.Targets[0].Email != "jdoe@example.com" {
+		t.Error("modifying the group modified the roster")
+	}
+}
+
+func TestTitleCase(t *testing.T) {
+	tests := map[string]string{
+		"jANE":          "Jane",
+		"mary ann":      "Mary Ann",
+		"smith-JONES":   "Smith-Jones",
+		"":              "",
+		"élodie":        "Élodie",
+		"van der  berg": "Van Der  Berg",
+	}
+	for in, want := range tests {
+		if got := titleCase(in); got != want {
+			t.Errorf("titleCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
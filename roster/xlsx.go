@@ -0,0 +1,216 @@
+package roster
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ParseXLSX parses a roster from a worksheet of an XLSX workbook, the first
+// row being the header. Only cell values are read, formulas are taken as
+// their cached result.
+func ParseXLSX(r io.ReaderAt, size int64, opts Options) (*Result, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("roster: invalid XLSX file: %v", err)
+	}
+
+	book := xlsxBook{files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		book.files[f.Name] = f
+	}
+
+	sheet, err := book.sheetPath(opts.Sheet)
+	if err != nil {
+		return nil, err
+	}
+	strs, err := book.sharedStrings()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := book.rows(sheet, strs)
+	if err != nil {
+		return nil, err
+	}
+	return parseRows(rows, opts)
+}
+
+// xlsxBook reads the parts of an XLSX workbook needed to get at cell values.
+type xlsxBook struct {
+	files map[string]*zip.File
+}
+
+func (b xlsxBook) decode(name string, v interface{}) error {
+	f, ok := b.files[name]
+	if !ok {
+		return fmt.Errorf("roster: invalid XLSX file: missing %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("roster: invalid XLSX file: %s: %v", name, err)
+	}
+	return nil
+}
+
+// sheetPath resolves the path of the named worksheet (or the first one)
+// within the archive.
+func (b xlsxBook) sheetPath(name string) (string, error) {
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := b.decode("xl/workbook.xml", &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", fmt.Errorf("roster: XLSX file has no worksheets")
+	}
+
+	rid := workbook.Sheets[0].RID
+	if name != "" {
+		rid = ""
+		for _, s := range workbook.Sheets {
+			if s.Name == name {
+				rid = s.RID
+			}
+		}
+		if rid == "" {
+			return "", fmt.Errorf("roster: no worksheet named %q", name)
+		}
+	}
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := b.decode("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != rid {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return "", fmt.Errorf("roster: invalid XLSX file: missing worksheet %s", rid)
+}
+
+// xlsxText is rich or plain text, as found in shared and inline strings.
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var sb strings.Builder
+	for _, r := range t.Runs {
+		sb.WriteString(r.T)
+	}
+	return sb.String()
+}
+
+func (b xlsxBook) sharedStrings() ([]string, error) {
+	if _, ok := b.files["xl/sharedStrings.xml"]; !ok {
+		// Workbooks with only inline strings or numbers have no shared
+		// strings.
+		return nil, nil
+	}
+
+	var sst struct {
+		Items []xlsxText `xml:"si"`
+	}
+	if err := b.decode("xl/sharedStrings.xml", &sst); err != nil {
+		return nil, err
+	}
+	strs := make([]string, len(sst.Items))
+	for i, item := range sst.Items {
+		strs[i] = item.String()
+	}
+	return strs, nil
+}
+
+func (b xlsxBook) rows(sheet string, strs []string) ([][]string, error) {
+	var ws struct {
+		Rows []struct {
+			Num   int `xml:"r,attr"`
+			Cells []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := b.decode(sheet, &ws); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(ws.Rows))
+	for _, row := range ws.Rows {
+		// Empty rows are omitted, pad them back in so that row numbers
+		// in errors match the spreadsheet.
+		for row.Num > 0 && len(rows) < row.Num-1 {
+			rows = append(rows, nil)
+		}
+
+		var values []string
+		for i, cell := range row.Cells {
+			col := i
+			if cell.Ref != "" {
+				col = columnIndex(cell.Ref)
+			}
+			for len(values) <= col {
+				values = append(values, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err != nil || idx < 0 || idx >= len(strs) {
+					return nil, fmt.Errorf("roster: invalid XLSX file: bad shared string in %s", cell.Ref)
+				}
+				values[col] = strs[idx]
+			case "inlineStr":
+				values[col] = cell.Inline.String()
+			default:
+				values[col] = cell.Value
+			}
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+// columnIndex converts the column of a cell reference to a 0-based index,
+// i.e. "B7" gives 1 and "AA1" gives 26.
+func columnIndex(ref string) int {
+	col := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	return col - 1
+}
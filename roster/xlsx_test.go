@@ -0,0 +1,133 @@
+package roster
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ttacon/gophish"
+)
+
+// newXLSX builds a minimal workbook with the given worksheets (name to
+// sheetData XML) and shared strings.
+func newXLSX(t *testing.T, sheets [][2]string, strs []string) *bytes.Reader {
+	t.Helper()
+
+	var workbook, rels strings.Builder
+	workbook.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	rels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	files := map[string]string{}
+	for i, sheet := range sheets {
+		id := string(rune('1' + i))
+		workbook.WriteString(`<sheet name="` + sheet[0] + `" sheetId="` + id + `" r:id="rId` + id + `"/>`)
+		rels.WriteString(`<Relationship Id="rId` + id + `" Target="worksheets/sheet` + id + `.xml"/>`)
+		files["xl/worksheets/sheet"+id+".xml"] = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			sheet[1] + `</sheetData></worksheet>`
+	}
+	workbook.WriteString(`</sheets></workbook>`)
+	rels.WriteString(`</Relationships>`)
+	files["xl/workbook.xml"] = workbook.String()
+	files["xl/_rels/workbook.xml.rels"] = rels.String()
+
+	if strs != nil {
+		var sst strings.Builder
+		sst.WriteString(`<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+		for _, s := range strs {
+			sst.WriteString(`<si><t>` + s + `</t></si>`)
+		}
+		sst.WriteString(`</sst>`)
+		files["xl/sharedStrings.xml"] = sst.String()
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func parseXLSX(r *bytes.Reader, opts Options) (*Result, error) {
+	return ParseXLSX(r, r.Size(), opts)
+}
+
+func TestParseXLSX(t *testing.T) {
+	strs := []string{"Mail", "Title", "jdoe@example.com", "Analyst"}
+	staff := `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>` +
+		`<row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2" t="s"><v>3</v></c></row>` +
+		// Row 3 is empty, and so left out of the sheet.
+		`<row r="4"><c r="A4" t="inlineStr"><is><r><t>asmith@</t></r><r><t>example.com</t></r></is></c><c r="C4"><v>42</v></c></row>` +
+		`<row r="5"><c r="A5" t="inlineStr"><is><t>not an email</t></is></c></row>`
+	r := newXLSX(t, [][2]string{
+		{"Contractors", `<row r="1"><c r="A1" t="inlineStr"><is><t>Email</t></is></c></row>`},
+		{"Staff", staff},
+	}, strs)
+
+	result, err := parseXLSX(r, Options{
+		Sheet: "Staff",
+		Columns: map[string]Field{
+			"mail":  FieldEmail,
+			"title": FieldPosition,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []gophish.Target{
+		{Email: "jdoe@example.com", Position: "Analyst"},
+		{Email: "asmith@example.com", Position: "42"},
+	}
+	if !reflect.DeepEqual(result.Targets, want) {
+		t.Errorf("got %+v, want %+v", result.Targets, want)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Row != 5 {
+		t.Errorf("got errors %v, want one for row 5", result.Errors)
+	}
+
+	// The first sheet is read by default.
+	result, err = parseXLSX(r, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Targets) != 0 || len(result.Errors) != 0 {
+		t.Errorf("got %+v, want the empty first sheet", result)
+	}
+}
+
+func TestParseXLSXErrors(t *testing.T) {
+	r := newXLSX(t, [][2]string{
+		{"Staff", `<row r="1"><c r="A1" t="s"><v>7</v></c></row>`},
+	}, []string{"Email"})
+
+	if _, err := parseXLSX(r, Options{Sheet: "Missing"}); err == nil {
+		t.Error("missing sheet: got no error")
+	}
+	if _, err := parseXLSX(r, Options{}); err == nil {
+		t.Error("bad shared string: got no error")
+	}
+
+	garbage := bytes.NewReader([]byte("not a zip file"))
+	if _, err := parseXLSX(garbage, Options{}); err == nil {
+		t.Error("not a zip file: got no error")
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := map[string]int{"A1": 0, "B7": 1, "Z3": 25, "AA1": 26, "AB10": 27}
+	for ref, want := range tests {
+		if got := columnIndex(ref); got != want {
+			t.Errorf("columnIndex(%q) = %d, want %d", ref, got, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package gophish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -23,7 +24,7 @@ type SendingProfile struct {
 	InterfaceType    string   `json:"interface_type"`
 	FromAddress      string   `json:"from_address"`
 	IgnoreCertErrors bool     `json:"ignore_cert_errors"`
-	ModifiedDate     string   `json:"modified_date"`
+	ModifiedDate     Time     `json:"modified_date"`
 	Headers          []Header `json:"headers"`
 }
 
@@ -36,10 +37,16 @@ type Header struct {
 // ListSendingProfiles retrieves a list of the sending profiles created by the
 // authenticated user.
 func (ss *SendingProfilesService) ListSendingProfiles() ([]SendingProfile, error) {
-	resp, err := ss.MakeRequest("GET", "/api/smtp", nil)
+	return ss.ListSendingProfilesContext(context.Background())
+}
+
+// ListSendingProfilesContext is like ListSendingProfiles, but uses the given context.
+func (ss *SendingProfilesService) ListSendingProfilesContext(ctx context.Context) ([]SendingProfile, error) {
+	resp, err := ss.MakeRequestContext(ctx, "GET", "/api/smtp", nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var profiles []SendingProfile
 	if err := json.NewDecoder(resp.Body).Decode(&profiles); err != nil {
@@ -48,10 +55,17 @@ func (ss *SendingProfilesService) ListSendingProfiles() ([]SendingProfile, error
 	return profiles, nil
 }
 
-// GetSendingProfile returns a sending profile given an ID, returning a 404
-// error if no sending profile with the provided ID is found.
+// GetSendingProfile returns a sending profile given an ID, returning an
+// *APIError satisfying IsNotFound if no sending profile with the provided ID
+// is found.
 func (ss *SendingProfilesService) GetSendingProfile(id int) (*SendingProfile, error) {
-	resp, err := ss.MakeRequest(
+	return ss.GetSendingProfileContext(context.Background(), id)
+}
+
+// GetSendingProfileContext is like GetSendingProfile, but uses the given context.
+func (ss *SendingProfilesService) GetSendingProfileContext(ctx context.Context, id int) (*SendingProfile, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("/api/smtp/%d", id),
 		nil,
@@ -59,6 +73,7 @@ func (ss *SendingProfilesService) GetSendingProfile(id int) (*SendingProfile, er
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var profile SendingProfile
 	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
@@ -69,10 +84,16 @@ func (ss *SendingProfilesService) GetSendingProfile(id int) (*SendingProfile, er
 
 // CreateSendingProfile creates a sending profile.
 func (ss *SendingProfilesService) CreateSendingProfile(sp *SendingProfile) (*SendingProfile, error) {
-	resp, err := ss.MakeRequest("POST", "/api/smtp", sp)
+	return ss.CreateSendingProfileContext(context.Background(), sp)
+}
+
+// CreateSendingProfileContext is like CreateSendingProfile, but uses the given context.
+func (ss *SendingProfilesService) CreateSendingProfileContext(ctx context.Context, sp *SendingProfile) (*SendingProfile, error) {
+	resp, err := ss.MakeRequestContext(ctx, "POST", "/api/smtp", sp)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var profile SendingProfile
 	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
@@ -83,7 +104,13 @@ func (ss *SendingProfilesService) CreateSendingProfile(sp *SendingProfile) (*Sen
 
 // UpdateSendingProfile modifies an existing sending profile.
 func (ss *SendingProfilesService) UpdateSendingProfile(sp *SendingProfile) (*SendingProfile, error) {
-	resp, err := ss.MakeRequest(
+	return ss.UpdateSendingProfileContext(context.Background(), sp)
+}
+
+// UpdateSendingProfileContext is like UpdateSendingProfile, but uses the given context.
+func (ss *SendingProfilesService) UpdateSendingProfileContext(ctx context.Context, sp *SendingProfile) (*SendingProfile, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"PUT",
 		fmt.Sprintf("/api/smtp/%d", sp.ID),
 		sp,
@@ -91,6 +118,7 @@ func (ss *SendingProfilesService) UpdateSendingProfile(sp *SendingProfile) (*Sen
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var profile SendingProfile
 	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
@@ -101,7 +129,13 @@ func (ss *SendingProfilesService) UpdateSendingProfile(sp *SendingProfile) (*Sen
 
 // DeleteSendingProfile deletes a sending profile by ID.
 func (ss *SendingProfilesService) DeleteSendingProfile(id int) (bool, error) {
-	resp, err := ss.MakeRequest(
+	return ss.DeleteSendingProfileContext(context.Background(), id)
+}
+
+// DeleteSendingProfileContext is like DeleteSendingProfile, but uses the given context.
+func (ss *SendingProfilesService) DeleteSendingProfileContext(ctx context.Context, id int) (bool, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"DELETE",
 		fmt.Sprintf("/api/smtp/%d", id),
 		nil,
@@ -109,6 +143,7 @@ func (ss *SendingProfilesService) DeleteSendingProfile(id int) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	defer resp.Body.Close()
 
 	return resp.StatusCode == http.StatusOK, nil
 }
@@ -0,0 +1,86 @@
+package gophish_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ttacon/gophish"
+)
+
+func TestSendingProfilesCRUD(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	created, err := client.SendingProfiles.CreateSendingProfile(&gophish.SendingProfile{
+		Name:        "Corp SMTP",
+		Host:        "smtp.example.com",
+		FromAddress: "IT <it@example.com>",
+		Username:    "it",
+		Password:    "hunter2",
+		Headers:     []gophish.Header{{Key: "X-Mailer", Value: "Outlook"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == 0 {
+		t.Error("created profile has no ID")
+	}
+	if created.Host != "smtp.example.com:25" || created.InterfaceType != "SMTP" {
+		t.Errorf("got host %q and interface %q, want gophish's defaults", created.Host, created.InterfaceType)
+	}
+	if !created.ModifiedDate.Equal(now) {
+		t.Errorf("got modified date %v, want %v", created.ModifiedDate, now)
+	}
+
+	got, err := client.SendingProfiles.GetSendingProfile(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, created) {
+		t.Errorf("got %+v, want %+v", got, created)
+	}
+
+	got.Password = "correct horse"
+	updated, err := client.SendingProfiles.UpdateSendingProfile(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Password != "correct horse" {
+		t.Errorf("got password %q after updating it", updated.Password)
+	}
+
+	list, err := client.SendingProfiles.ListSendingProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].ID != created.ID {
+		t.Errorf("got %+v, want the created profile", list)
+	}
+
+	ok, err := client.SendingProfiles.DeleteSendingProfile(created.ID)
+	if err != nil || !ok {
+		t.Fatalf("got %v, %v deleting the profile", ok, err)
+	}
+	if _, err := client.SendingProfiles.GetSendingProfile(created.ID); !gophish.IsNotFound(err) {
+		t.Errorf("got error %v after deleting the profile, want not found", err)
+	}
+	if _, err := client.SendingProfiles.DeleteSendingProfile(created.ID); !gophish.IsNotFound(err) {
+		t.Errorf("got error %v deleting the profile twice, want not found", err)
+	}
+}
+
+func TestSendingProfilesValidation(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	invalid := []gophish.SendingProfile{
+		{Name: "No host", FromAddress: "it@example.com"},
+		{Name: "No from", Host: "smtp.example.com"},
+		{Name: "Bad from", Host: "smtp.example.com", FromAddress: "not an address"},
+	}
+	for _, sp := range invalid {
+		if _, err := client.SendingProfiles.CreateSendingProfile(&sp); !gophish.IsBadRequest(err) {
+			t.Errorf("%s: got error %v, want bad request", sp.Name, err)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package gophish
+
+import "fmt"
+
+// CampaignStatus is the status of a campaign as a whole.
+type CampaignStatus string
+
+// The statuses a campaign goes through, in order.
+const (
+	CampaignCreated    CampaignStatus = "Created"
+	CampaignQueued     CampaignStatus = "Queued"
+	CampaignInProgress CampaignStatus = "In progress"
+	CampaignEmailsSent CampaignStatus = "Emails Sent"
+	CampaignCompleted  CampaignStatus = "Completed"
+)
+
+var campaignStatuses = []CampaignStatus{
+	CampaignCreated,
+	CampaignQueued,
+	CampaignInProgress,
+	CampaignEmailsSent,
+	CampaignCompleted,
+}
+
+// ParseCampaignStatus parses the display text gophish uses for a campaign
+// status.
+func ParseCampaignStatus(s string) (CampaignStatus, error) {
+	for _, status := range campaignStatuses {
+		if string(status) == s {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("gophish: unknown campaign status %q", s)
+}
+
+// ResultStatus is the status of a single recipient of a campaign.
+type ResultStatus string
+
+// The statuses of a campaign result. Once an email has been sent, a result's
+// status only moves forward: Email Sent < Email Opened < Clicked Link <
+// Submitted Data.
+const (
+	ResultScheduled     ResultStatus = "Scheduled"
+	ResultQueued        ResultStatus = "Queued"
+	ResultSending       ResultStatus = "Sending"
+	ResultRetrying      ResultStatus = "Retrying"
+	ResultError         ResultStatus = "Error"
+	ResultSendingError  ResultStatus = "Error Sending Email"
+	ResultUnknown       ResultStatus = "Unknown"
+	ResultEmailSent     ResultStatus = "Email Sent"
+	ResultEmailOpened   ResultStatus = "Email Opened"
+	ResultClickedLink   ResultStatus = "Clicked Link"
+	ResultSubmittedData ResultStatus = "Submitted Data"
+	ResultEmailReported ResultStatus = "Email Reported"
+)
+
+// resultRanks orders result statuses by how far a recipient got. Statuses
+// before an email is successfully sent all share the lowest rank, and a
+// reported email says nothing about whether it was opened, so it ranks the
+// same as a sent one.
+var resultRanks = map[ResultStatus]int{
+	ResultScheduled:     0,
+	ResultQueued:        0,
+	ResultSending:       0,
+	ResultRetrying:      0,
+	ResultError:         0,
+	ResultSendingError:  0,
+	ResultUnknown:       0,
+	ResultEmailSent:     1,
+	ResultEmailReported: 1,
+	ResultEmailOpened:   2,
+	ResultClickedLink:   3,
+	ResultSubmittedData: 4,
+}
+
+// ParseResultStatus parses the display text gophish uses for a result
+// status.
+func ParseResultStatus(s string) (ResultStatus, error) {
+	status := ResultStatus(s)
+	if _, ok := resultRanks[status]; !ok {
+		return "", fmt.Errorf("gophish: unknown result status %q", s)
+	}
+	return status, nil
+}
+
+// Rank returns how far a recipient with this status got, from 0 (not sent
+// yet, or failed to send) to 4 (submitted data). Unknown statuses rank 0.
+func (s ResultStatus) Rank() int {
+	return resultRanks[s]
+}
+
+// Less reports whether s ranks strictly before other.
+func (s ResultStatus) Less(other ResultStatus) bool {
+	return s.Rank() < other.Rank()
+}
+
+// AtLeast reports whether s ranks the same as or after other, i.e. whether a
+// recipient that has clicked a link has at least opened the email.
+func (s ResultStatus) AtLeast(other ResultStatus) bool {
+	return s.Rank() >= other.Rank()
+}
+
+// EventMessage is the kind of an event in a campaign's timeline.
+type EventMessage string
+
+// The events that make up a campaign's timeline.
+const (
+	EventCampaignCreated EventMessage = "Campaign Created"
+	EventEmailSent       EventMessage = "Email Sent"
+	EventSendingError    EventMessage = "Error Sending Email"
+	EventEmailOpened     EventMessage = "Email Opened"
+	EventClickedLink     EventMessage = "Clicked Link"
+	EventSubmittedData   EventMessage = "Submitted Data"
+	EventEmailReported   EventMessage = "Email Reported"
+	EventProxiedRequest  EventMessage = "Proxied request"
+)
+
+var eventMessages = []EventMessage{
+	EventCampaignCreated,
+	EventEmailSent,
+	EventSendingError,
+	EventEmailOpened,
+	EventClickedLink,
+	EventSubmittedData,
+	EventEmailReported,
+	EventProxiedRequest,
+}
+
+// ParseEventMessage parses the display text gophish uses for an event.
+func ParseEventMessage(s string) (EventMessage, error) {
+	for _, msg := range eventMessages {
+		if string(msg) == s {
+			return msg, nil
+		}
+	}
+	return "", fmt.Errorf("gophish: unknown event message %q", s)
+}
+
+// HasBeenSent reports whether the email was sent to the recipient.
+func (r CampaignResult) HasBeenSent() bool {
+	return r.Status.AtLeast(ResultEmailSent)
+}
+
+// HasOpened reports whether the recipient opened the email.
+func (r CampaignResult) HasOpened() bool {
+	return r.Status.AtLeast(ResultEmailOpened)
+}
+
+// HasClicked reports whether the recipient clicked the link in the email.
+func (r CampaignResult) HasClicked() bool {
+	return r.Status.AtLeast(ResultClickedLink)
+}
+
+// HasSubmitted reports whether the recipient submitted data on the landing
+// page.
+func (r CampaignResult) HasSubmitted() bool {
+	return r.Status.AtLeast(ResultSubmittedData)
+}
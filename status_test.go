@@ -0,0 +1,146 @@
+package gophish
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestResultStatusRank(t *testing.T) {
+	tests := []struct {
+		status ResultStatus
+		rank   int
+	}{
+		{ResultScheduled, 0},
+		{ResultQueued, 0},
+		{ResultSending, 0},
+		{ResultRetrying, 0},
+		{ResultError, 0},
+		{ResultSendingError, 0},
+		{ResultUnknown, 0},
+		{ResultStatus("Not a status"), 0},
+		{ResultStatus(""), 0},
+		{ResultEmailSent, 1},
+		{ResultEmailReported, 1},
+		{ResultEmailOpened, 2},
+		{ResultClickedLink, 3},
+		{ResultSubmittedData, 4},
+	}
+	for _, tt := range tests {
+		if got := tt.status.Rank(); got != tt.rank {
+			t.Errorf("%q.Rank() = %d, want %d", tt.status, got, tt.rank)
+		}
+	}
+}
+
+func TestResultStatusOrder(t *testing.T) {
+	tests := []struct {
+		a, b    ResultStatus
+		less    bool
+		atLeast bool
+	}{
+		{ResultEmailSent, ResultEmailOpened, true, false},
+		{ResultEmailOpened, ResultEmailSent, false, true},
+		{ResultClickedLink, ResultClickedLink, false, true},
+		{ResultSubmittedData, ResultClickedLink, false, true},
+		{ResultScheduled, ResultEmailSent, true, false},
+		{ResultEmailReported, ResultEmailSent, false, true},
+		{ResultEmailReported, ResultEmailOpened, true, false},
+		{ResultError, ResultQueued, false, true},
+		// Unknown statuses rank with those before sending.
+		{ResultStatus("Not a status"), ResultEmailSent, true, false},
+		{ResultStatus("Not a status"), ResultScheduled, false, true},
+		{ResultEmailSent, ResultStatus("Not a status"), false, true},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Less(tt.b); got != tt.less {
+			t.Errorf("%q.Less(%q) = %v, want %v", tt.a, tt.b, got, tt.less)
+		}
+		if got := tt.a.AtLeast(tt.b); got != tt.atLeast {
+			t.Errorf("%q.AtLeast(%q) = %v, want %v", tt.a, tt.b, got, tt.atLeast)
+		}
+	}
+}
+
+func TestStatusJSON(t *testing.T) {
+	var campaign struct {
+		Status CampaignStatus `json:"status"`
+		Result ResultStatus   `json:"result"`
+		Event  EventMessage   `json:"message"`
+	}
+	const data = `{"status":"In progress","result":"Clicked Link","message":"Email Opened"}`
+	if err := json.Unmarshal([]byte(data), &campaign); err != nil {
+		t.Fatal(err)
+	}
+	if campaign.Status != CampaignInProgress || campaign.Result != ResultClickedLink || campaign.Event != EventEmailOpened {
+		t.Errorf("got %+v", campaign)
+	}
+	out, err := json.Marshal(campaign)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != data {
+		t.Errorf("got %s, want %s", out, data)
+	}
+}
+
+func TestParseStatuses(t *testing.T) {
+	for _, status := range campaignStatuses {
+		if got, err := ParseCampaignStatus(string(status)); err != nil || got != status {
+			t.Errorf("ParseCampaignStatus(%q) = %q, %v", status, got, err)
+		}
+	}
+	for status := range resultRanks {
+		if got, err := ParseResultStatus(string(status)); err != nil || got != status {
+			t.Errorf("ParseResultStatus(%q) = %q, %v", status, got, err)
+		}
+	}
+	for _, msg := range eventMessages {
+		if got, err := ParseEventMessage(string(msg)); err != nil || got != msg {
+			t.Errorf("ParseEventMessage(%q) = %q, %v", msg, got, err)
+		}
+	}
+
+	// gophish's display text is case sensitive.
+	if _, err := ParseCampaignStatus("in progress"); err == nil {
+		t.Error("parsed an unknown campaign status")
+	}
+	if _, err := ParseResultStatus("clicked link"); err == nil {
+		t.Error("parsed an unknown result status")
+	}
+	if _, err := ParseEventMessage(""); err == nil {
+		t.Error("parsed an unknown event message")
+	}
+}
+
+func TestCampaignResultProgress(t *testing.T) {
+	tests := []struct {
+		status                           ResultStatus
+		sent, opened, clicked, submitted bool
+	}{
+		{ResultScheduled, false, false, false, false},
+		{ResultSendingError, false, false, false, false},
+		{ResultEmailSent, true, false, false, false},
+		{ResultEmailReported, true, false, false, false},
+		{ResultEmailOpened, true, true, false, false},
+		// Clicking implies the email was opened, even if gophish never saw
+		// it opened (i.e. images weren't loaded).
+		{ResultClickedLink, true, true, true, false},
+		{ResultSubmittedData, true, true, true, true},
+		{ResultStatus("Not a status"), false, false, false, false},
+	}
+	for _, tt := range tests {
+		r := CampaignResult{Status: tt.status}
+		if got := r.HasBeenSent(); got != tt.sent {
+			t.Errorf("%q: HasBeenSent() = %v", tt.status, got)
+		}
+		if got := r.HasOpened(); got != tt.opened {
+			t.Errorf("%q: HasOpened() = %v", tt.status, got)
+		}
+		if got := r.HasClicked(); got != tt.clicked {
+			t.Errorf("%q: HasClicked() = %v", tt.status, got)
+		}
+		if got := r.HasSubmitted(); got != tt.submitted {
+			t.Errorf("%q: HasSubmitted() = %v", tt.status, got)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package gophish
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// arrayStream decodes the elements of a JSON array from a response body one
+// at a time, rather than buffering the whole array.
+type arrayStream struct {
+	body    io.ReadCloser
+	dec     *json.Decoder
+	started bool
+	done    bool
+	err     error
+}
+
+func newArrayStream(body io.ReadCloser) arrayStream {
+	return arrayStream{
+		body: body,
+		dec:  json.NewDecoder(body),
+	}
+}
+
+// next decodes the next element of the array into v, returning false once
+// the array is exhausted or an error occurs.
+func (s *arrayStream) next(v interface{}) bool {
+	if s.done {
+		return false
+	}
+
+	if !s.started {
+		s.started = true
+		tok, err := s.dec.Token()
+		if err != nil {
+			return s.fail(err)
+		}
+		if tok == nil {
+			// gophish responds with null rather than [] in some cases.
+			return s.fail(nil)
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return s.fail(fmt.Errorf("gophish: expected a JSON array, got %v", tok))
+		}
+	}
+
+	if !s.dec.More() {
+		// Consume the closing bracket so that malformed input is reported.
+		if _, err := s.dec.Token(); err != nil {
+			return s.fail(err)
+		}
+		return s.fail(nil)
+	}
+
+	if err := s.dec.Decode(v); err != nil {
+		return s.fail(err)
+	}
+	return true
+}
+
+func (s *arrayStream) fail(err error) bool {
+	s.err = err
+	s.done = true
+	s.body.Close()
+	return false
+}
+
+func (s *arrayStream) close() error {
+	s.done = true
+	return s.body.Close()
+}
+
+// CampaignIterator iterates over campaigns as they are decoded from the
+// response, so that large lists of campaigns (with their results and
+// timelines) don't need to be held in memory all at once.
+//
+//	it, err := client.Campaigns.StreamCampaigns()
+//	if err != nil {
+//		return err
+//	}
+//	defer it.Close()
+//
+//	for it.Next() {
+//		campaign := it.Campaign()
+//		// ...
+//	}
+//	return it.Err()
+type CampaignIterator struct {
+	stream   arrayStream
+	campaign Campaign
+}
+
+// Next advances to the next campaign, returning false once there are no
+// more campaigns or an error occurs.
+func (it *CampaignIterator) Next() bool {
+	it.campaign = Campaign{}
+	return it.stream.next(&it.campaign)
+}
+
+// Campaign returns the current campaign.
+func (it *CampaignIterator) Campaign() *Campaign {
+	return &it.campaign
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *CampaignIterator) Err() error {
+	return it.stream.err
+}
+
+// Close releases the underlying response, it's safe to call more than once.
+func (it *CampaignIterator) Close() error {
+	return it.stream.close()
+}
+
+// GroupIterator is like CampaignIterator, but for groups.
+type GroupIterator struct {
+	stream arrayStream
+	group  Group
+}
+
+// Next advances to the next group, returning false once there are no more
+// groups or an error occurs.
+func (it *GroupIterator) Next() bool {
+	it.group = Group{}
+	return it.stream.next(&it.group)
+}
+
+// Group returns the current group.
+func (it *GroupIterator) Group() *Group {
+	return &it.group
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *GroupIterator) Err() error {
+	return it.stream.err
+}
+
+// Close releases the underlying response, it's safe to call more than once.
+func (it *GroupIterator) Close() error {
+	return it.stream.close()
+}
@@ -1,6 +1,7 @@
 package gophish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -17,7 +18,7 @@ type Template struct {
 	Subject      string       `json:"subject"`
 	Text         string       `json:"text"`
 	HTML         string       `json:"html"`
-	ModifiedDate string       `json:"modified_date"`
+	ModifiedDate Time         `json:"modified_date"`
 	Attachments  []Attachment `json:"attachments"`
 }
 
@@ -35,10 +36,16 @@ type TemplatesService struct {
 
 // ListTemplates returns a list of templates.
 func (ss *TemplatesService) ListTemplates() ([]Template, error) {
-	resp, err := ss.MakeRequest("GET", "/api/templates", nil)
+	return ss.ListTemplatesContext(context.Background())
+}
+
+// ListTemplatesContext is like ListTemplates, but uses the given context.
+func (ss *TemplatesService) ListTemplatesContext(ctx context.Context) ([]Template, error) {
+	resp, err := ss.MakeRequestContext(ctx, "GET", "/api/templates", nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var templates []Template
 	if err := json.NewDecoder(resp.Body).Decode(&templates); err != nil {
@@ -49,7 +56,13 @@ func (ss *TemplatesService) ListTemplates() ([]Template, error) {
 
 // GetTemplate retrieves a template given an ID.
 func (ss *TemplatesService) GetTemplate(id int) (*Template, error) {
-	resp, err := ss.MakeRequest(
+	return ss.GetTemplateContext(context.Background(), id)
+}
+
+// GetTemplateContext is like GetTemplate, but uses the given context.
+func (ss *TemplatesService) GetTemplateContext(ctx context.Context, id int) (*Template, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("/api/templates/%d", id),
 		nil,
@@ -57,6 +70,7 @@ func (ss *TemplatesService) GetTemplate(id int) (*Template, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var profile Template
 	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
@@ -67,10 +81,16 @@ func (ss *TemplatesService) GetTemplate(id int) (*Template, error) {
 
 // CreateTemplate creates a new template.
 func (ss *TemplatesService) CreateTemplate(sp *Template) (*Template, error) {
-	resp, err := ss.MakeRequest("POST", "/api/templates", sp)
+	return ss.CreateTemplateContext(context.Background(), sp)
+}
+
+// CreateTemplateContext is like CreateTemplate, but uses the given context.
+func (ss *TemplatesService) CreateTemplateContext(ctx context.Context, sp *Template) (*Template, error) {
+	resp, err := ss.MakeRequestContext(ctx, "POST", "/api/templates", sp)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var profile Template
 	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
@@ -81,7 +101,13 @@ func (ss *TemplatesService) CreateTemplate(sp *Template) (*Template, error) {
 
 // UpdateTemplate updates the given template.
 func (ss *TemplatesService) UpdateTemplate(sp *Template) (*Template, error) {
-	resp, err := ss.MakeRequest(
+	return ss.UpdateTemplateContext(context.Background(), sp)
+}
+
+// UpdateTemplateContext is like UpdateTemplate, but uses the given context.
+func (ss *TemplatesService) UpdateTemplateContext(ctx context.Context, sp *Template) (*Template, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"PUT",
 		fmt.Sprintf("/api/templates/%d", sp.ID),
 		sp,
@@ -89,6 +115,7 @@ func (ss *TemplatesService) UpdateTemplate(sp *Template) (*Template, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var template Template
 	if err := json.NewDecoder(resp.Body).Decode(&template); err != nil {
@@ -99,7 +126,13 @@ func (ss *TemplatesService) UpdateTemplate(sp *Template) (*Template, error) {
 
 // DeleteTemplate deletes a template given an ID.
 func (ss *TemplatesService) DeleteTemplate(id int) (bool, error) {
-	resp, err := ss.MakeRequest(
+	return ss.DeleteTemplateContext(context.Background(), id)
+}
+
+// DeleteTemplateContext is like DeleteTemplate, but uses the given context.
+func (ss *TemplatesService) DeleteTemplateContext(ctx context.Context, id int) (bool, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"DELETE",
 		fmt.Sprintf("/api/templates/%d", id),
 		nil,
@@ -107,6 +140,7 @@ func (ss *TemplatesService) DeleteTemplate(id int) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	defer resp.Body.Close()
 
 	return resp.StatusCode == http.StatusOK, nil
 }
@@ -120,7 +154,13 @@ func (ss *TemplatesService) DeleteTemplate(id int) (bool, error) {
 // the original headers. Usually, this is found using the "Show Original"
 // feature of email clients.
 func (ss *TemplatesService) ImportTemplate(imp ImportRequest) (*Template, error) {
-	resp, err := ss.MakeRequest(
+	return ss.ImportTemplateContext(context.Background(), imp)
+}
+
+// ImportTemplateContext is like ImportTemplate, but uses the given context.
+func (ss *TemplatesService) ImportTemplateContext(ctx context.Context, imp ImportRequest) (*Template, error) {
+	resp, err := ss.MakeRequestContext(
+		ctx,
 		"POST",
 		"/api/import/email",
 		imp,
@@ -128,6 +168,7 @@ func (ss *TemplatesService) ImportTemplate(imp ImportRequest) (*Template, error)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var template Template
 	if err := json.NewDecoder(resp.Body).Decode(&template); err != nil {
@@ -0,0 +1,130 @@
+package gophish_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ttacon/gophish"
+)
+
+func TestTemplatesCRUD(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	created, err := client.Templates.CreateTemplate(&gophish.Template{
+		Name:    "Password reset",
+		Subject: "Your password expires today",
+		HTML:    `<a href="{{.URL}}">Reset</a>`,
+		Attachments: []gophish.Attachment{{
+			Name:    "policy.txt",
+			Type:    "text/plain",
+			Content: "cG9saWN5",
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == 0 || !created.ModifiedDate.Equal(now) {
+		t.Errorf("got %+v, want an ID and modified date", created)
+	}
+
+	got, err := client.Templates.GetTemplate(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, created) {
+		t.Errorf("got %+v, want %+v", got, created)
+	}
+
+	got.Text = "Reset your password at {{.URL}}"
+	updated, err := client.Templates.UpdateTemplate(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Text != got.Text || len(updated.Attachments) != 1 {
+		t.Errorf("got %+v after updating", updated)
+	}
+
+	missing := *updated
+	missing.ID += 100
+	if _, err := client.Templates.UpdateTemplate(&missing); !gophish.IsNotFound(err) {
+		t.Errorf("got error %v updating a missing template, want not found", err)
+	}
+
+	if _, err := client.Templates.CreateTemplate(&gophish.Template{
+		Name: "Password reset",
+		Text: "duplicate",
+	}); err == nil {
+		t.Error("created a template with a duplicate name")
+	}
+
+	list, err := client.Templates.ListTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Name != "Password reset" {
+		t.Errorf("got %+v, want the created template", list)
+	}
+
+	if ok, err := client.Templates.DeleteTemplate(created.ID); err != nil || !ok {
+		t.Fatalf("got %v, %v deleting the template", ok, err)
+	}
+	if _, err := client.Templates.GetTemplate(created.ID); !gophish.IsNotFound(err) {
+		t.Errorf("got error %v after deleting the template, want not found", err)
+	}
+}
+
+const email = "From: IT <it@example.com>\r\n" +
+	"To: jdoe@example.com\r\n" +
+	"Subject: =?utf-8?q?Password_expiry?=\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/alternative; boundary=BOUNDARY\r\n" +
+	"\r\n" +
+	"--BOUNDARY\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"\r\n" +
+	"Reset at https://example.com/reset\r\n" +
+	"--BOUNDARY\r\n" +
+	"Content-Type: text/html\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"<a href=3D\"https://example.com/reset\">Reset</a>\r\n" +
+	"--BOUNDARY--\r\n"
+
+func TestImportTemplate(t *testing.T) {
+	srv, client := newTestServer(t)
+	defer srv.Close()
+
+	tmpl, err := client.Templates.ImportTemplate(gophish.ImportRequest{
+		Content:      email,
+		ConvertLinks: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Subject != "Password expiry" {
+		t.Errorf("got subject %q", tmpl.Subject)
+	}
+	if !strings.Contains(tmpl.Text, "https://example.com/reset") {
+		t.Errorf("got text %q", tmpl.Text)
+	}
+	if !strings.Contains(tmpl.HTML, `href="{{.URL}}"`) {
+		t.Errorf("got html %q, want the link converted", tmpl.HTML)
+	}
+
+	// Importing doesn't save the template.
+	list, err := client.Templates.ListTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 0 {
+		t.Errorf("got %d templates after importing, want 0", len(list))
+	}
+
+	if _, err := client.Templates.ImportTemplate(gophish.ImportRequest{
+		Content: "not an email",
+	}); !gophish.IsBadRequest(err) {
+		t.Errorf("got error %v importing garbage, want bad request", err)
+	}
+}
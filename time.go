@@ -0,0 +1,55 @@
+package gophish
+
+import (
+	"bytes"
+	"time"
+)
+
+// zeroDate is how gophish represents timestamps that haven't been set (i.e.
+// the CompletedDate of a campaign that's still in progress).
+const zeroDate = "0001-01-01T00:00:00Z"
+
+// Time is a timestamp in a gophish resource. It marshals to and from the
+// RFC 3339 representation that gophish uses, and treats gophish's zero date
+// ("0001-01-01T00:00:00Z"), null and "" as unset.
+type Time struct {
+	time.Time
+}
+
+// NewTime wraps t as a Time.
+func NewTime(t time.Time) Time {
+	return Time{Time: t}
+}
+
+// IsSet reports whether the timestamp has been set.
+func (t Time) IsSet() bool {
+	return !t.IsZero()
+}
+
+// MarshalJSON implements json.Marshaler. Unset timestamps are marshaled as
+// gophish's zero date.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`"` + zeroDate + `"`), nil
+	}
+	return t.Time.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) || bytes.Equal(data, []byte(`""`)) {
+		*t = Time{}
+		return nil
+	}
+
+	var parsed time.Time
+	if err := parsed.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	if parsed.IsZero() {
+		// Normalize zero dates so that unset timestamps compare equal.
+		parsed = time.Time{}
+	}
+	*t = Time{Time: parsed}
+	return nil
+}
@@ -0,0 +1,106 @@
+package gophish
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeRoundTrip(t *testing.T) {
+	tests := []string{
+		`"2020-05-08T15:07:01Z"`,
+		`"2020-05-08T15:07:01-05:00"`,
+		`"2020-05-08T15:07:01+05:30"`,
+		`"2020-05-08T15:07:01.585379-05:00"`,
+		`"2020-05-08T15:07:01.123456789Z"`,
+	}
+	for _, in := range tests {
+		var ts Time
+		if err := json.Unmarshal([]byte(in), &ts); err != nil {
+			t.Errorf("%s: %v", in, err)
+			continue
+		}
+		if !ts.IsSet() {
+			t.Errorf("%s: got an unset time", in)
+		}
+		out, err := json.Marshal(ts)
+		if err != nil {
+			t.Errorf("%s: %v", in, err)
+			continue
+		}
+		if string(out) != in {
+			t.Errorf("%s: marshaled back to %s", in, out)
+		}
+	}
+}
+
+func TestTimePreservesInstant(t *testing.T) {
+	var ts Time
+	if err := json.Unmarshal([]byte(`"2020-05-08T15:07:01.000000042-05:00"`), &ts); err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2020, 5, 8, 20, 7, 1, 42, time.UTC)
+	if !ts.Equal(want) {
+		t.Errorf("got %v, want %v", ts.Time, want)
+	}
+	if _, offset := ts.Zone(); offset != -5*60*60 {
+		t.Errorf("got offset %d, want -5h", offset)
+	}
+}
+
+func TestTimeUnset(t *testing.T) {
+	tests := []string{
+		`"0001-01-01T00:00:00Z"`,
+		`"0001-01-01T00:00:00+00:00"`,
+		`null`,
+		`""`,
+	}
+	for _, in := range tests {
+		ts := NewTime(time.Now())
+		if err := json.Unmarshal([]byte(in), &ts); err != nil {
+			t.Errorf("%s: %v", in, err)
+			continue
+		}
+		if ts.IsSet() {
+			t.Errorf("%s: got a set time %v", in, ts.Time)
+		}
+		if ts != (Time{}) {
+			t.Errorf("%s: got %#v, want the zero Time", in, ts)
+		}
+		out, err := json.Marshal(ts)
+		if err != nil {
+			t.Errorf("%s: %v", in, err)
+			continue
+		}
+		if string(out) != `"`+zeroDate+`"` {
+			t.Errorf("%s: marshaled back to %s, want gophish's zero date", in, out)
+		}
+	}
+}
+
+func TestTimeInvalid(t *testing.T) {
+	for _, in := range []string{`"yesterday"`, `42`, `"2020-05-08"`} {
+		var ts Time
+		if err := json.Unmarshal([]byte(in), &ts); err == nil {
+			t.Errorf("%s: got %v, want an error", in, ts.Time)
+		}
+	}
+}
+
+func TestTimeInModels(t *testing.T) {
+	var c Campaign
+	err := json.Unmarshal([]byte(`{
+		"created_date": "2020-05-08T15:07:01.585379-05:00",
+		"completed_date": "0001-01-01T00:00:00Z",
+		"send_by_date": null
+	}`), &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.CreatedDate.IsSet() {
+		t.Error("created_date is unset")
+	}
+	if c.CompletedDate.IsSet() || c.SendByDate.IsSet() || c.LaunchDate.IsSet() {
+		t.Errorf("got set dates %+v, want them unset", c)
+	}
+}
@@ -0,0 +1,106 @@
+package gophish
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// Validate checks the sending profile has what gophish requires, so mistakes
+// can be caught before making a request.
+func (sp *SendingProfile) Validate() error {
+	switch {
+	case sp.Name == "":
+		return errors.New("gophish: sending profile has no name")
+	case sp.Host == "":
+		return errors.New("gophish: sending profile has no SMTP host")
+	case sp.FromAddress == "":
+		return errors.New("gophish: sending profile has no from address")
+	}
+	if _, err := mail.ParseAddress(sp.FromAddress); err != nil {
+		return fmt.Errorf("gophish: sending profile has an invalid from address %q", sp.FromAddress)
+	}
+	for _, h := range sp.Headers {
+		if h.Key == "" {
+			return errors.New("gophish: sending profile has a header without a name")
+		}
+	}
+	return nil
+}
+
+// Validate checks the template has what gophish requires.
+func (t *Template) Validate() error {
+	switch {
+	case t.Name == "":
+		return errors.New("gophish: template has no name")
+	case t.Text == "" && t.HTML == "":
+		return errors.New("gophish: template has neither text nor HTML")
+	}
+	for _, a := range t.Attachments {
+		if a.Name == "" {
+			return errors.New("gophish: template has an attachment without a name")
+		}
+	}
+	return nil
+}
+
+// Validate checks the landing page has what gophish requires.
+func (p *LandingPage) Validate() error {
+	switch {
+	case p.Name == "":
+		return errors.New("gophish: landing page has no name")
+	case p.CapturePasswords && !p.CaptureCredentials:
+		return errors.New("gophish: landing page captures passwords without capturing credentials")
+	}
+	return nil
+}
+
+// Validate checks the group has a name and targets with valid, unique email
+// addresses.
+func (g *Group) Validate() error {
+	switch {
+	case g.Name == "":
+		return errors.New("gophish: group has no name")
+	case len(g.Targets) == 0:
+		return fmt.Errorf("gophish: group %q has no targets", g.Name)
+	}
+	seen := make(map[string]bool, len(g.Targets))
+	for _, t := range g.Targets {
+		if _, err := mail.ParseAddress(t.Email); err != nil {
+			return fmt.Errorf("gophish: group %q has an invalid email address %q", g.Name, t.Email)
+		}
+		key := strings.ToLower(t.Email)
+		if seen[key] {
+			return fmt.Errorf("gophish: group %q has %q more than once", g.Name, t.Email)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// Validate checks the campaign names everything gophish needs to launch it.
+// Gophish looks the template, page, sending profile and groups up by name.
+func (c *Campaign) Validate() error {
+	switch {
+	case c.Name == "":
+		return errors.New("gophish: campaign has no name")
+	case c.Template.Name == "":
+		return fmt.Errorf("gophish: campaign %q has no template", c.Name)
+	case c.Page.Name == "":
+		return fmt.Errorf("gophish: campaign %q has no landing page", c.Name)
+	case c.SMTP.Name == "":
+		return fmt.Errorf("gophish: campaign %q has no sending profile", c.Name)
+	case len(c.Groups) == 0:
+		return fmt.Errorf("gophish: campaign %q has no groups", c.Name)
+	}
+	for _, g := range c.Groups {
+		if g.Name == "" {
+			return fmt.Errorf("gophish: campaign %q has a group without a name", c.Name)
+		}
+	}
+	if c.SendByDate.IsSet() && c.SendByDate.Before(c.LaunchDate.Time) {
+		return fmt.Errorf("gophish: campaign %q must be sent by a date after it launches", c.Name)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package gophish
+
+import (
+	"testing"
+	"time"
+)
+
+// checkValidate checks err is nil when want is empty, or has the message want.
+func checkValidate(t *testing.T, name string, err error, want string) {
+	t.Helper()
+	switch {
+	case want == "" && err != nil:
+		t.Errorf("%s: got error %v", name, err)
+	case want != "" && err == nil:
+		t.Errorf("%s: got no error, want %q", name, want)
+	case want != "" && err.Error() != want:
+		t.Errorf("%s: got error %q, want %q", name, err, want)
+	}
+}
+
+func TestSendingProfileValidate(t *testing.T) {
+	valid := func() SendingProfile {
+		return SendingProfile{
+			Name:        "Corp SMTP",
+			Host:        "smtp.example.com:25",
+			FromAddress: "IT <it@example.com>",
+			Headers:     []Header{{Key: "X-Mailer", Value: "Outlook"}},
+		}
+	}
+	tests := []struct {
+		name   string
+		modify func(*SendingProfile)
+		want   string
+	}{
+		{"valid", func(*SendingProfile) {}, ""},
+		{"no headers", func(sp *SendingProfile) { sp.Headers = nil }, ""},
+		{"no name", func(sp *SendingProfile) { sp.Name = "" }, "gophish: sending profile has no name"},
+		{"no host", func(sp *SendingProfile) { sp.Host = "" }, "gophish: sending profile has no SMTP host"},
+		{"no from address", func(sp *SendingProfile) { sp.FromAddress = "" }, "gophish: sending profile has no from address"},
+		{
+			"invalid from address",
+			func(sp *SendingProfile) { sp.FromAddress = "IT department" },
+			`gophish: sending profile has an invalid from address "IT department"`,
+		},
+		{
+			"header without a name",
+			func(sp *SendingProfile) { sp.Headers = append(sp.Headers, Header{Value: "1"}) },
+			"gophish: sending profile has a header without a name",
+		},
+	}
+	for _, tt := range tests {
+		sp := valid()
+		tt.modify(&sp)
+		checkValidate(t, tt.name, sp.Validate(), tt.want)
+	}
+}
+
+func TestTemplateValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template Template
+		want     string
+	}{
+		{"text", Template{Name: "Invoice", Text: "Hi"}, ""},
+		{"HTML", Template{Name: "Invoice", HTML: "<p>Hi</p>"}, ""},
+		{
+			"attachment",
+			Template{Name: "Invoice", Text: "Hi", Attachments: []Attachment{{Name: "invoice.pdf"}}},
+			"",
+		},
+		{"no name", Template{Text: "Hi"}, "gophish: template has no name"},
+		{"no content", Template{Name: "Invoice"}, "gophish: template has neither text nor HTML"},
+		{
+			"attachment without a name",
+			Template{Name: "Invoice", HTML: "<p>Hi</p>", Attachments: []Attachment{{Type: "application/pdf"}}},
+			"gophish: template has an attachment without a name",
+		},
+	}
+	for _, tt := range tests {
+		checkValidate(t, tt.name, tt.template.Validate(), tt.want)
+	}
+}
+
+func TestLandingPageValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		page LandingPage
+		want string
+	}{
+		{"valid", LandingPage{Name: "Login"}, ""},
+		{"credentials", LandingPage{Name: "Login", CaptureCredentials: true}, ""},
+		{"passwords", LandingPage{Name: "Login", CaptureCredentials: true, CapturePasswords: true}, ""},
+		{"no name", LandingPage{HTML: "<form></form>"}, "gophish: landing page has no name"},
+		{
+			"passwords without credentials",
+			LandingPage{Name: "Login", CapturePasswords: true},
+			"gophish: landing page captures passwords without capturing credentials",
+		},
+	}
+	for _, tt := range tests {
+		checkValidate(t, tt.name, tt.page.Validate(), tt.want)
+	}
+}
+
+func TestGroupValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		group Group
+		want  string
+	}{
+		{
+			"valid",
+			Group{Name: "Staff", Targets: []Target{{Email: "jdoe@example.com"}, {Email: "bwayne@example.com"}}},
+			"",
+		},
+		{"no name", Group{Targets: []Target{{Email: "jdoe@example.com"}}}, "gophish: group has no name"},
+		{"no targets", Group{Name: "Staff"}, `gophish: group "Staff" has no targets`},
+		{
+			"invalid email",
+			Group{Name: "Staff", Targets: []Target{{Email: "jdoe@example.com"}, {Email: "bwayne"}}},
+			`gophish: group "Staff" has an invalid email address "bwayne"`,
+		},
+		{
+			"no email",
+			Group{Name: "Staff", Targets: []Target{{FirstName: "John"}}},
+			`gophish: group "Staff" has an invalid email address ""`,
+		},
+		{
+			"duplicate email",
+			Group{Name: "Staff", Targets: []Target{{Email: "jdoe@example.com"}, {Email: "JDoe@Example.com"}}},
+			`gophish: group "Staff" has "JDoe@Example.com" more than once`,
+		},
+	}
+	for _, tt := range tests {
+		checkValidate(t, tt.name, tt.group.Validate(), tt.want)
+	}
+}
+
+func TestCampaignValidate(t *testing.T) {
+	launch := time.Date(2020, 5, 8, 15, 7, 1, 0, time.UTC)
+	valid := func() Campaign {
+		return Campaign{
+			Name:     "Q2 phishing test",
+			Template: Template{Name: "Invoice"},
+			Page:     LandingPage{Name: "Login"},
+			SMTP:     SendingProfile{Name: "Corp SMTP"},
+			Groups:   []Group{{Name: "Staff"}},
+		}
+	}
+	tests := []struct {
+		name   string
+		modify func(*Campaign)
+		want   string
+	}{
+		{"valid", func(*Campaign) {}, ""},
+		{
+			"send by date",
+			func(c *Campaign) {
+				c.LaunchDate = NewTime(launch)
+				c.SendByDate = NewTime(launch.Add(time.Hour))
+			},
+			"",
+		},
+		{"no name", func(c *Campaign) { c.Name = "" }, "gophish: campaign has no name"},
+		{"no template", func(c *Campaign) { c.Template.Name = "" }, `gophish: campaign "Q2 phishing test" has no template`},
+		{"no page", func(c *Campaign) { c.Page.Name = "" }, `gophish: campaign "Q2 phishing test" has no landing page`},
+		{"no sending profile", func(c *Campaign) { c.SMTP.Name = "" }, `gophish: campaign "Q2 phishing test" has no sending profile`},
+		{"no groups", func(c *Campaign) { c.Groups = nil }, `gophish: campaign "Q2 phishing test" has no groups`},
+		{
+			"group without a name",
+			func(c *Campaign) { c.Groups = append(c.Groups, Group{ID: 2}) },
+			`gophish: campaign "Q2 phishing test" has a group without a name`,
+		},
+		{
+			"send by date before launch",
+			func(c *Campaign) {
+				c.LaunchDate = NewTime(launch)
+				c.SendByDate = NewTime(launch.Add(-time.Hour))
+			},
+			`gophish: campaign "Q2 phishing test" must be sent by a date after it launches`,
+		},
+	}
+	for _, tt := range tests {
+		c := valid()
+		tt.modify(&c)
+		checkValidate(t, tt.name, c.Validate(), tt.want)
+	}
+}